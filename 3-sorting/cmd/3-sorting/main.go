@@ -1,7 +1,201 @@
 package main
 
-import ()
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/phanty133/id1021/3-sorted/pkg/sorting"
+)
+
+type Sort struct {
+	Name string
+	Sort func([]int)
+	// Largest size the sort is benchmarked at, 0 for no limit
+	MaxSize int
+	// Instrumented variant of Sort, nil if the sort has no comparator
+	Counted func([]int, sorting.Comparator[int])
+}
+
+func GenRandIntArr(n, min, max int) []int {
+	arr := make([]int, n)
+
+	for i := 0; i < n; i++ {
+		arr[i] = rand.Intn(max-min) + min
+	}
+
+	return arr
+}
+
+func BenchSort(sort func([]int), benchArrays [][]int, repeats int) [][]float64 {
+	times := make([][]float64, len(benchArrays))
+
+	for i, arr := range benchArrays {
+		times[i] = make([]float64, repeats)
+
+		for r := 0; r < repeats; r++ {
+			arrCopy := make([]int, len(arr))
+			copy(arrCopy, arr)
+
+			start := time.Now()
+			sort(arrCopy)
+			elapsed := time.Since(start)
+
+			times[i][r] = float64(elapsed.Nanoseconds()) / 1000 / 1000
+		}
+	}
+
+	return times
+}
+
+// Runs the instrumented variant of sort once on a copy of each array.
+func CountSort(sort func([]int, sorting.Comparator[int]), benchArrays [][]int) []sorting.Stats {
+	stats := make([]sorting.Stats, len(benchArrays))
+
+	for i, arr := range benchArrays {
+		arrCopy := make([]int, len(arr))
+		copy(arrCopy, arr)
+
+		c := sorting.Instrument(cmp.Compare[int], &stats[i])
+		stats[i].Track(func() { sort(arrCopy, c) })
+	}
+
+	return stats
+}
+
+// Writes one column per benchmarked sort and size, one row per run. The stat
+// columns of a sort follow its time column.
+func WriteTimes(name string, header []string, times [][]string) {
+	file, err := os.Create(name)
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	if err := writer.Write(append([]string{"#"}, header...)); err != nil {
+		panic(err)
+	}
+
+	rows := 0
+
+	for _, col := range times {
+		rows = max(rows, len(col))
+	}
+
+	for i := 0; i < rows; i++ {
+		row := make([]string, len(times)+1)
+		row[0] = fmt.Sprintf("%d", i)
+
+		for j, col := range times {
+			if i < len(col) {
+				row[j+1] = col[i]
+			}
+		}
+
+		if err := writer.Write(row); err != nil {
+			panic(err)
+		}
+	}
+
+	writer.Flush()
+}
 
 func main() {
+	sizes := []int{100, 1000, 2500, 5000, 10000, 25000, 50000, 75000, 100000, 250000}
+	arrays := 10
+	repeats := 10
+	maxVal := 1000000
+	statNames := []string{"comparisons", "swaps", "moves", "depth", "bytes"}
+
+	sorts := []Sort{
+		{"Selection", sorting.SelectionSort[int], 50000, sorting.SelectionSortFunc[int]},
+		{"Insertion", sorting.InsertionSort[int], 250000, sorting.InsertionSortFunc[int]},
+		{"Merge", sorting.MergeSort[int], 0, sorting.MergeSortFunc[int]},
+		{"Merge2", sorting.MergeSort2[int], 0, sorting.MergeSort2Func[int]},
+		{"RadixLSD", sorting.RadixSortLSD[int], 0, nil},
+		{"RadixMSD", sorting.RadixSortMSD[int], 0, nil},
+		{"Counting", sorting.CountingSort[int], 0, nil},
+		{"Bucket", func(arr []int) {
+			sorting.BucketSortFunc(arr, func(v int) float64 { return float64(v) })
+		}, 0, nil},
+	}
+
+	header := []string{}
+	times := [][]string{}
+
+	for _, size := range sizes {
+		fmt.Printf("Size: %d\n", size)
+
+		benchArrays := make([][]int, arrays)
+
+		for i := range benchArrays {
+			benchArrays[i] = GenRandIntArr(size, 0, maxVal)
+		}
+
+		for _, s := range sorts {
+			if s.MaxSize != 0 && size > s.MaxSize {
+				continue
+			}
+
+			fmt.Printf("%s\n", s.Name)
+
+			col := []string{}
+
+			for _, arrTimes := range BenchSort(s.Sort, benchArrays, repeats) {
+				for _, t := range arrTimes {
+					col = append(col, fmt.Sprintf("%f", t))
+				}
+			}
+
+			header = append(header, fmt.Sprintf("%s %d", s.Name, size))
+			times = append(times, col)
+
+			// Counts are per array, so they're repeated for each of its runs
+			counted := s.Counted
+
+			if counted == nil {
+				counted = func(arr []int, _ sorting.Comparator[int]) { s.Sort(arr) }
+			}
+
+			counts := make([][]string, len(statNames))
+
+			for _, stats := range CountSort(counted, benchArrays) {
+				vals := []string{
+					fmt.Sprintf("%d", stats.Comparisons),
+					fmt.Sprintf("%d", stats.Swaps),
+					fmt.Sprintf("%d", stats.Moves),
+					fmt.Sprintf("%d", stats.MaxDepth),
+					fmt.Sprintf("%d", stats.Bytes),
+				}
+
+				// Sorts without a Func variant aren't instrumented, only their
+				// allocations are measured
+				if s.Counted == nil {
+					for i := 0; i < 4; i++ {
+						vals[i] = "n/a"
+					}
+				}
+
+				for i, val := range vals {
+					for r := 0; r < repeats; r++ {
+						counts[i] = append(counts[i], val)
+					}
+				}
+			}
+
+			for i, name := range statNames {
+				header = append(header, fmt.Sprintf("%s %d %s", s.Name, size, name))
+				times = append(times, counts[i])
+			}
+		}
+	}
 
+	WriteTimes("bench.csv", header, times)
 }
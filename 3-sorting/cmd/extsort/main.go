@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/phanty133/id1021/3-sorted/pkg/extsort"
+)
+
+/*
+Sorts a CSV file by one column without loading it fully into memory.
+
+Usage: extsort [flags] [input.csv]
+
+Reads from stdin if no input file is given.
+*/
+
+func main() {
+	column := flag.Int("column", 0, "zero-based column to sort by")
+	numeric := flag.Bool("numeric", false, "compare the column as a number instead of as a string")
+	header := flag.Bool("header", false, "keep the first row in place as a header")
+	memMB := flag.Int("mem", extsort.DefaultMemoryBudget>>20, "memory budget per run in MiB")
+	fanIn := flag.Int("fanin", extsort.DefaultMaxFanIn, "largest number of run files merged at once")
+	tempDir := flag.String("tmp", "", "directory for run files (default system temp directory)")
+	comma := flag.String("comma", ",", "field delimiter")
+	outPath := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	if len([]rune(*comma)) != 1 {
+		fmt.Fprintln(os.Stderr, "delimiter must be a single character")
+		os.Exit(2)
+	}
+
+	opts := extsort.Options{
+		Column:       *column,
+		Numeric:      *numeric,
+		Header:       *header,
+		MemoryBudget: *memMB << 20,
+		TempDir:      *tempDir,
+		Comma:        []rune(*comma)[0],
+		MaxFanIn:     *fanIn,
+	}
+
+	var inPath string
+
+	if flag.NArg() > 0 {
+		inPath = flag.Arg(0)
+	}
+
+	if err := run(inPath, *outPath, opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Sorts inPath into outPath, using stdin or stdout for an empty path.
+func run(inPath, outPath string, opts extsort.Options) error {
+	if inPath != "" && outPath != "" {
+		// SortFile refuses to truncate the input by writing over it
+		return extsort.SortFile(inPath, outPath, opts)
+	}
+
+	in := os.Stdin
+
+	if inPath != "" {
+		file, err := os.Open(inPath)
+
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+		in = file
+	}
+
+	if outPath == "" {
+		return extsort.Sort(in, os.Stdout, opts)
+	}
+
+	out, err := os.Create(outPath)
+
+	if err != nil {
+		return err
+	}
+
+	if err := extsort.Sort(in, out, opts); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
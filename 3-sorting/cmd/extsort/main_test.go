@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/phanty133/id1021/3-sorted/pkg/extsort"
+)
+
+func TestRunSamePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+
+	if err := os.WriteFile(path, []byte("b\na\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run(path, path, extsort.Options{}); err == nil {
+		t.Fatal("sorting a file into itself wasn't rejected")
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "b\na\n" {
+		t.Fatalf("input was changed to %q", data)
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.csv")
+	out := filepath.Join(dir, "out.csv")
+
+	if err := os.WriteFile(in, []byte("b\na\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run(in, out, extsort.Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, _ := os.ReadFile(out); string(data) != "a\nb\n" {
+		t.Fatalf("got %q, want \"a\\nb\\n\"", data)
+	}
+}
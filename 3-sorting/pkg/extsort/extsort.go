@@ -0,0 +1,446 @@
+// External merge sort for CSV files that don't fit in memory. The input is
+// read in runs that fit in the memory budget, each run is sorted and written
+// to a temporary file, and the runs are then k-way merged with a min-heap.
+// At most MaxFanIn runs are merged at once, so very large inputs are merged
+// in several passes instead of opening every run file at the same time.
+
+package extsort
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultMemoryBudget = 64 << 20
+	DefaultMaxFanIn     = 64
+)
+
+type Options struct {
+	// Zero-based index of the column to sort by
+	Column int
+	// Compare the column as a number instead of as a string. Whitespace
+	// inside the field is ignored, so grouped numbers like "111 15" parse.
+	Numeric bool
+	// Copy the first record to the output as is instead of sorting it
+	Header bool
+	// Approximate number of bytes of records held in memory at once,
+	// DefaultMemoryBudget if zero
+	MemoryBudget int
+	// Directory for the run files, the system temp directory if empty
+	TempDir string
+	// Field delimiter, ',' if zero
+	Comma rune
+	// Largest number of run files merged at once, DefaultMaxFanIn if zero
+	MaxFanIn int
+}
+
+type record struct {
+	fields []string
+	num    float64
+}
+
+type sorter struct {
+	opts Options
+	runs []string
+}
+
+// Sorts the CSV records read from r by the configured column and writes them
+// to w. The sort is stable.
+func Sort(r io.Reader, w io.Writer, opts Options) error {
+	if opts.MemoryBudget <= 0 {
+		opts.MemoryBudget = DefaultMemoryBudget
+	}
+
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+
+	if opts.MaxFanIn <= 0 {
+		opts.MaxFanIn = DefaultMaxFanIn
+	}
+
+	if opts.MaxFanIn < 2 {
+		opts.MaxFanIn = 2
+	}
+
+	if opts.Column < 0 {
+		return fmt.Errorf("invalid column %d", opts.Column)
+	}
+
+	s := &sorter{opts: opts}
+	defer s.cleanup()
+
+	reader := s.newReader(r)
+	run := []record{}
+	runSize := 0
+	line := 0
+
+	var header []string
+
+	if opts.Header {
+		fields, err := reader.Read()
+
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		header = fields
+		line++
+	}
+
+	for {
+		fields, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		line++
+		rec, err := s.parse(fields)
+
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		run = append(run, rec)
+		runSize += recordSize(fields)
+
+		if runSize >= opts.MemoryBudget {
+			if err := s.writeRun(run); err != nil {
+				return err
+			}
+
+			run = run[:0]
+			runSize = 0
+		}
+	}
+
+	if len(s.runs) == 0 {
+		// Everything fit in memory, no need to go through the disk
+		s.sortRun(run)
+		return s.writeRecords(w, header, run)
+	}
+
+	if len(run) > 0 {
+		if err := s.writeRun(run); err != nil {
+			return err
+		}
+	}
+
+	runs, err := s.mergePasses(s.runs)
+
+	if err != nil {
+		return err
+	}
+
+	return s.merge(w, header, runs)
+}
+
+// Sorts the CSV file at inPath into outPath. The paths may not be the same.
+func SortFile(inPath, outPath string, opts Options) error {
+	if same, err := samePath(inPath, outPath); err != nil {
+		return err
+	} else if same {
+		return fmt.Errorf("input and output are both %s", inPath)
+	}
+
+	in, err := os.Open(inPath)
+
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.Create(outPath)
+
+	if err != nil {
+		return err
+	}
+
+	if err := Sort(in, out, opts); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// Reports whether the paths refer to the same file, including through links.
+func samePath(a, b string) (bool, error) {
+	absA, err := filepath.Abs(a)
+
+	if err != nil {
+		return false, err
+	}
+
+	absB, err := filepath.Abs(b)
+
+	if err != nil {
+		return false, err
+	}
+
+	if absA == absB {
+		return true, nil
+	}
+
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+
+	// The output doesn't have to exist yet
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB), nil
+}
+
+func (s *sorter) newReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.Comma = s.opts.Comma
+	reader.FieldsPerRecord = -1
+
+	return reader
+}
+
+func (s *sorter) parse(fields []string) (record, error) {
+	if s.opts.Column >= len(fields) {
+		return record{}, fmt.Errorf("no column %d", s.opts.Column)
+	}
+
+	rec := record{fields: fields}
+
+	if s.opts.Numeric {
+		digits := strings.Join(strings.Fields(fields[s.opts.Column]), "")
+		num, err := strconv.ParseFloat(digits, 64)
+
+		if err != nil {
+			return record{}, err
+		}
+
+		rec.num = num
+	}
+
+	return rec, nil
+}
+
+// Rough in-memory size of a record: the field contents plus the string and
+// slice headers.
+func recordSize(fields []string) int {
+	size := 48
+
+	for _, f := range fields {
+		size += len(f) + 16
+	}
+
+	return size
+}
+
+func (s *sorter) compare(a, b record) int {
+	if s.opts.Numeric {
+		return cmp.Compare(a.num, b.num)
+	}
+
+	return strings.Compare(a.fields[s.opts.Column], b.fields[s.opts.Column])
+}
+
+func (s *sorter) sortRun(run []record) {
+	slices.SortStableFunc(run, s.compare)
+}
+
+// Writes the header, if any, and the records to w.
+func (s *sorter) writeRecords(w io.Writer, header []string, run []record) error {
+	buf := bufio.NewWriter(w)
+	writer := csv.NewWriter(buf)
+	writer.Comma = s.opts.Comma
+
+	if header != nil {
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for _, rec := range run {
+		if err := writer.Write(rec.fields); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	return buf.Flush()
+}
+
+func (s *sorter) writeRun(run []record) error {
+	s.sortRun(run)
+
+	file, err := s.createRun()
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.writeRecords(file, nil, run); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Creates a temporary run file, which is removed in cleanup.
+func (s *sorter) createRun() (*os.File, error) {
+	file, err := os.CreateTemp(s.opts.TempDir, "extsort-run-*.csv")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.runs = append(s.runs, file.Name())
+
+	return file, nil
+}
+
+// Merges consecutive groups of MaxFanIn runs into longer runs until at most
+// MaxFanIn are left. Merging neighbouring runs keeps the sort stable.
+func (s *sorter) mergePasses(runs []string) ([]string, error) {
+	for len(runs) > s.opts.MaxFanIn {
+		merged := []string{}
+
+		for start := 0; start < len(runs); start += s.opts.MaxFanIn {
+			group := runs[start:min(start+s.opts.MaxFanIn, len(runs))]
+
+			if len(group) == 1 {
+				merged = append(merged, group[0])
+				continue
+			}
+
+			file, err := s.createRun()
+
+			if err != nil {
+				return nil, err
+			}
+
+			if err := s.merge(file, nil, group); err != nil {
+				file.Close()
+				return nil, err
+			}
+
+			if err := file.Close(); err != nil {
+				return nil, err
+			}
+
+			// The merged runs aren't needed anymore, don't wait for cleanup
+			for _, path := range group {
+				os.Remove(path)
+			}
+
+			merged = append(merged, file.Name())
+		}
+
+		runs = merged
+	}
+
+	return runs, nil
+}
+
+func (s *sorter) cleanup() {
+	for _, path := range s.runs {
+		os.Remove(path)
+	}
+}
+
+// K-way merges the sorted run files into w, after the header if there is one.
+func (s *sorter) merge(w io.Writer, header []string, runs []string) error {
+	h := &runHeap{compare: s.compare}
+
+	for i, path := range runs {
+		file, err := os.Open(path)
+
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		c := &runCursor{run: i, reader: s.newReader(file)}
+		ok, err := s.advance(c)
+
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			h.add(c)
+		}
+	}
+
+	buf := bufio.NewWriter(w)
+	writer := csv.NewWriter(buf)
+	writer.Comma = s.opts.Comma
+
+	if header != nil {
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for !h.empty() {
+		c := h.min()
+
+		if err := writer.Write(c.rec.fields); err != nil {
+			return err
+		}
+
+		ok, err := s.advance(c)
+
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			h.fix()
+		} else {
+			h.remove()
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	return buf.Flush()
+}
+
+// Reads the next record of the cursor's run. Returns false once the run is
+// exhausted.
+func (s *sorter) advance(c *runCursor) (bool, error) {
+	fields, err := c.reader.Read()
+
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	c.rec, err = s.parse(fields)
+	return err == nil, err
+}
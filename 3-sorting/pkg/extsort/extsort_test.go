@@ -0,0 +1,125 @@
+package extsort
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/phanty133/id1021/3-sorted/pkg/sortcheck"
+)
+
+// Sorts the items as CSV rows of key and index through extsort, with a budget
+// small enough to force several runs for the larger inputs, and a fan-in small
+// enough to merge them in several passes.
+func sortItems(t *testing.T, items []sortcheck.Item, numeric bool) {
+	var in, out bytes.Buffer
+
+	for _, item := range items {
+		key := strconv.Itoa(item.Key)
+
+		if !numeric {
+			// Offset and pad so string order matches numeric order
+			key = fmt.Sprintf("%08d", item.Key+1<<20)
+		}
+
+		fmt.Fprintf(&in, "%s,%d\n", key, item.Idx)
+	}
+
+	opts := Options{Numeric: numeric, MemoryBudget: 1024, TempDir: t.TempDir(), MaxFanIn: 3}
+
+	if err := Sort(&in, &out, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, record := range records {
+		key, _ := strconv.Atoi(record[0])
+		idx, _ := strconv.Atoi(record[1])
+
+		if !numeric {
+			key -= 1 << 20
+		}
+
+		items[i] = sortcheck.Item{Key: key, Idx: idx}
+	}
+}
+
+func TestSort(t *testing.T) {
+	for _, numeric := range []bool{false, true} {
+		t.Run(fmt.Sprintf("numeric=%t", numeric), func(t *testing.T) {
+			sortcheck.RunStable(t, func(items []sortcheck.Item) {
+				sortItems(t, items, numeric)
+			}, sortcheck.IntGenerators(1<<20-1))
+		})
+	}
+}
+
+func TestSortErrors(t *testing.T) {
+	var out bytes.Buffer
+
+	if err := Sort(bytes.NewBufferString("a,1\nb\n"), &out, Options{Column: 1}); err == nil {
+		t.Error("missing column not reported")
+	}
+
+	if err := Sort(bytes.NewBufferString("a,1\nb,x\n"), &out, Options{Column: 1, Numeric: true}); err == nil {
+		t.Error("non-numeric key not reported")
+	}
+}
+
+func TestSortHeaderAndGrouped(t *testing.T) {
+	var out bytes.Buffer
+
+	in := "postnummer,ort\n111 20,B\n981 32,C\n111 15,A\n"
+	opts := Options{Numeric: true, Header: true}
+
+	if err := Sort(bytes.NewBufferString(in), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "postnummer,ort\n111 15,A\n111 20,B\n981 32,C\n"
+
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+
+	// The header stays first when the runs are merged through the disk too
+	out.Reset()
+	opts.MemoryBudget = 1
+	opts.TempDir = t.TempDir()
+
+	if err := Sort(bytes.NewBufferString(in), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.String() != want {
+		t.Fatalf("with runs: got %q, want %q", out.String(), want)
+	}
+}
+
+func TestSortFileSamePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.csv")
+
+	if err := os.WriteFile(path, []byte("b\na\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	other := filepath.Join(dir, ".", "data.csv")
+
+	if err := SortFile(path, other, Options{}); err == nil {
+		t.Fatal("sorting a file into itself wasn't rejected")
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "b\na\n" {
+		t.Fatalf("input was changed to %q", data)
+	}
+}
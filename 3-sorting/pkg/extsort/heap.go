@@ -0,0 +1,81 @@
+package extsort
+
+import "encoding/csv"
+
+type runCursor struct {
+	rec    record
+	run    int
+	reader *csv.Reader
+}
+
+// Array min-heap of run cursors ordered by their current record. Ties are
+// broken by run index, which keeps the merge stable.
+type runHeap struct {
+	data    []*runCursor
+	compare func(a, b record) int
+}
+
+func (h *runHeap) empty() bool {
+	return len(h.data) == 0
+}
+
+func (h *runHeap) less(i, j int) bool {
+	c := h.compare(h.data[i].rec, h.data[j].rec)
+	return c < 0 || (c == 0 && h.data[i].run < h.data[j].run)
+}
+
+func (h *runHeap) min() *runCursor {
+	return h.data[0]
+}
+
+func (h *runHeap) add(c *runCursor) {
+	h.data = append(h.data, c)
+	n := len(h.data) - 1
+
+	for n > 0 {
+		parent := (n - 1) / 2
+
+		if !h.less(n, parent) {
+			break
+		}
+
+		h.data[parent], h.data[n] = h.data[n], h.data[parent]
+		n = parent
+	}
+}
+
+// Removes the minimum cursor.
+func (h *runHeap) remove() {
+	lastIdx := len(h.data) - 1
+	h.data[0] = h.data[lastIdx]
+	h.data = h.data[:lastIdx]
+	h.fix()
+}
+
+// Restores the heap after the minimum cursor has advanced.
+func (h *runHeap) fix() {
+	n := 0
+	dataLen := len(h.data)
+
+	for {
+		left := 2*n + 1
+		right := 2*n + 2
+
+		if left >= dataLen {
+			break
+		}
+
+		target := left
+
+		if right < dataLen && h.less(right, left) {
+			target = right
+		}
+
+		if !h.less(target, n) {
+			break
+		}
+
+		h.data[target], h.data[n] = h.data[n], h.data[target]
+		n = target
+	}
+}
@@ -0,0 +1,153 @@
+package sortcheck
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Returns an input of length n.
+type Generator[T any] func(rng *rand.Rand, n int) []T
+
+// Randomized and adversarial int inputs with values in [-limit, limit], or the
+// full int range if limit is 0. The adversarial cases target the usual weak
+// spots: presorted runs for naive pivots, duplicates for partitioning, and
+// extreme values for sign handling and overflow.
+func IntGenerators(limit int) map[string]Generator[int] {
+	lo, hi := -limit, limit
+
+	if limit == 0 {
+		lo, hi = math.MinInt, math.MaxInt
+	}
+
+	random := func(rng *rand.Rand) int {
+		if limit == 0 {
+			return int(rng.Uint64())
+		}
+
+		return rng.Intn(2*limit+1) - limit
+	}
+
+	fill := func(n int, f func(i int) int) []int {
+		arr := make([]int, n)
+
+		for i := range arr {
+			arr[i] = f(i)
+		}
+
+		return arr
+	}
+
+	// Evenly spaced values from lo to hi, without overflowing
+	spread := func(i, n int) int {
+		if n < 2 {
+			return 0
+		}
+
+		step := uint64(hi-lo) / uint64(n-1)
+		return lo + int(step*uint64(i))
+	}
+
+	return map[string]Generator[int]{
+		"random": func(rng *rand.Rand, n int) []int {
+			return fill(n, func(int) int { return random(rng) })
+		},
+		"sorted": func(rng *rand.Rand, n int) []int {
+			return fill(n, func(i int) int { return spread(i, n) })
+		},
+		"reversed": func(rng *rand.Rand, n int) []int {
+			return fill(n, func(i int) int { return spread(n-1-i, n) })
+		},
+		"equal": func(rng *rand.Rand, n int) []int {
+			v := random(rng)
+			return fill(n, func(int) int { return v })
+		},
+		"few-unique": func(rng *rand.Rand, n int) []int {
+			vals := []int{random(rng), random(rng), random(rng), random(rng)}
+			return fill(n, func(int) int { return vals[rng.Intn(len(vals))] })
+		},
+		"organ-pipe": func(rng *rand.Rand, n int) []int {
+			return fill(n, func(i int) int { return spread(min(i, n-1-i), n) })
+		},
+		"sawtooth": func(rng *rand.Rand, n int) []int {
+			return fill(n, func(i int) int { return spread(i%8, 8) })
+		},
+		"nearly-sorted": func(rng *rand.Rand, n int) []int {
+			arr := fill(n, func(i int) int { return spread(i, n) })
+
+			for k := 0; k < n/20+1 && n > 1; k++ {
+				i, j := rng.Intn(n), rng.Intn(n)
+				arr[i], arr[j] = arr[j], arr[i]
+			}
+
+			return arr
+		},
+		"extremes": func(rng *rand.Rand, n int) []int {
+			vals := []int{lo, hi, 0, -1, 1}
+			return fill(n, func(int) int { return vals[rng.Intn(len(vals))] })
+		},
+	}
+}
+
+// Random byte strings of up to 8 bytes over a small alphabet, so that shared
+// prefixes and duplicates are common, plus the adversarial cases.
+func StringGenerators() map[string]Generator[string] {
+	random := func(rng *rand.Rand) string {
+		b := make([]byte, rng.Intn(9))
+
+		for i := range b {
+			b[i] = "ab\x00\xff"[rng.Intn(4)]
+		}
+
+		return string(b)
+	}
+
+	fill := func(n int, f func(i int) string) []string {
+		arr := make([]string, n)
+
+		for i := range arr {
+			arr[i] = f(i)
+		}
+
+		return arr
+	}
+
+	return map[string]Generator[string]{
+		"random": func(rng *rand.Rand, n int) []string {
+			return fill(n, func(int) string { return random(rng) })
+		},
+		"equal": func(rng *rand.Rand, n int) []string {
+			s := random(rng)
+			return fill(n, func(int) string { return s })
+		},
+		"prefixes": func(rng *rand.Rand, n int) []string {
+			s := random(rng) + random(rng) + random(rng)
+			return fill(n, func(int) string { return s[:rng.Intn(len(s)+1)] })
+		},
+	}
+}
+
+// Random floats, including infinities and signed zeros.
+func FloatGenerators() map[string]Generator[float64] {
+	fill := func(n int, f func(i int) float64) []float64 {
+		arr := make([]float64, n)
+
+		for i := range arr {
+			arr[i] = f(i)
+		}
+
+		return arr
+	}
+
+	return map[string]Generator[float64]{
+		"uniform": func(rng *rand.Rand, n int) []float64 {
+			return fill(n, func(int) float64 { return rng.Float64() })
+		},
+		"normal": func(rng *rand.Rand, n int) []float64 {
+			return fill(n, func(int) float64 { return rng.NormFloat64() * 1e6 })
+		},
+		"extremes": func(rng *rand.Rand, n int) []float64 {
+			vals := []float64{math.Inf(-1), math.Inf(1), 0, math.Copysign(0, -1), -math.MaxFloat64, math.MaxFloat64}
+			return fill(n, func(int) float64 { return vals[rng.Intn(len(vals))] })
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package sortcheck
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var Sizes = []int{0, 1, 2, 3, 7, 31, 32, 33, 100, 1000}
+
+// Inputs are generated from a fixed seed so failures are reproducible.
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
+// Runs sort on every generated input at each of Sizes.
+func Run[T cmp.Ordered](t *testing.T, sort func([]T), gens map[string]Generator[T]) {
+	t.Helper()
+	rng := newRand()
+
+	for _, name := range sortedKeys(gens) {
+		for _, n := range Sizes {
+			in := gens[name](rng, n)
+
+			if err := Check(sort, in); err != nil {
+				t.Errorf("%s, n=%d: %v", name, n, err)
+			}
+		}
+	}
+}
+
+// Like Run, but for stable sorts of Items, keyed by the generated ints.
+func RunStable(t *testing.T, sort func([]Item), gens map[string]Generator[int]) {
+	t.Helper()
+	rng := newRand()
+
+	for _, name := range sortedKeys(gens) {
+		for _, n := range Sizes {
+			keys := gens[name](rng, n)
+
+			if err := CheckStable(sort, keys); err != nil {
+				t.Errorf("%s, n=%d: %v", name, n, err)
+			}
+		}
+	}
+}
+
+// Decodes fuzzer input as little endian int16s, giving a small value range
+// with plenty of duplicates.
+func DecodeInts(data []byte) []int {
+	arr := make([]int, len(data)/2)
+
+	for i := range arr {
+		arr[i] = int(int16(binary.LittleEndian.Uint16(data[2*i:])))
+	}
+
+	return arr
+}
+
+func encodeInts(arr []int) []byte {
+	data := make([]byte, 2*len(arr))
+
+	for i, v := range arr {
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(int16(v)))
+	}
+
+	return data
+}
+
+// Seeds f with the int16 range generators and fuzzes each of the named sorts
+// on the decoded input.
+func Fuzz(f *testing.F, sorts map[string]func([]int)) {
+	addSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		in := DecodeInts(data)
+
+		for _, name := range sortedKeys(sorts) {
+			if err := Check(sorts[name], in); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+	})
+}
+
+// Like Fuzz, for stable sorts of Items.
+func FuzzStable(f *testing.F, sorts map[string]func([]Item)) {
+	addSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		keys := DecodeInts(data)
+
+		for _, name := range sortedKeys(sorts) {
+			if err := CheckStable(sorts[name], keys); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+	})
+}
+
+func addSeeds(f *testing.F) {
+	rng := newRand()
+	gens := IntGenerators(1 << 15)
+
+	for _, name := range sortedKeys(gens) {
+		for _, n := range []int{0, 1, 2, 50} {
+			f.Add(encodeInts(clamp(gens[name](rng, n))))
+		}
+	}
+}
+
+// Clamps generated values to the int16 range DecodeInts produces.
+func clamp(arr []int) []int {
+	for i, v := range arr {
+		arr[i] = min(max(v, -1<<15), 1<<15-1)
+	}
+
+	return arr
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+// Verification harness for sorts. Checks that a sort's output is ordered, is a
+// permutation of its input and, for sorts that claim it, that equal elements
+// keep their relative order.
+
+package sortcheck
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Element for stability checks. Sorts under test must order by Key only; Idx
+// is the position of the element in the input.
+type Item struct {
+	Key int
+	Idx int
+}
+
+func (i Item) String() string {
+	return fmt.Sprintf("%d@%d", i.Key, i.Idx)
+}
+
+func CompareItems(a, b Item) int {
+	return cmp.Compare(a.Key, b.Key)
+}
+
+func NewItems(keys []int) []Item {
+	items := make([]Item, len(keys))
+
+	for i, k := range keys {
+		items[i] = Item{k, i}
+	}
+
+	return items
+}
+
+// Returns an error describing the first out of order pair in arr.
+func Sorted[T any](arr []T, cmp func(a, b T) int) error {
+	for i := 1; i < len(arr); i++ {
+		if cmp(arr[i-1], arr[i]) > 0 {
+			return fmt.Errorf("not sorted at index %d: %v > %v", i, arr[i-1], arr[i])
+		}
+	}
+
+	return nil
+}
+
+// Returns an error if out doesn't hold exactly the elements of in.
+func Permutation[T comparable](in, out []T) error {
+	if len(in) != len(out) {
+		return fmt.Errorf("length changed from %d to %d", len(in), len(out))
+	}
+
+	counts := make(map[T]int, len(in))
+
+	for _, v := range in {
+		counts[v]++
+	}
+
+	for _, v := range out {
+		counts[v]--
+	}
+
+	for v, c := range counts {
+		if c > 0 {
+			return fmt.Errorf("%d occurrence(s) of %v lost", c, v)
+		}
+
+		if c < 0 {
+			return fmt.Errorf("%d occurrence(s) of %v added", -c, v)
+		}
+	}
+
+	return nil
+}
+
+// Returns an error if out, sorted by key, reordered items with equal keys.
+func Stable(out []Item) error {
+	for i := 1; i < len(out); i++ {
+		if out[i-1].Key == out[i].Key && out[i-1].Idx > out[i].Idx {
+			return fmt.Errorf("unstable at index %d: key %d from input index %d before %d",
+				i, out[i].Key, out[i-1].Idx, out[i].Idx)
+		}
+	}
+
+	return nil
+}
+
+// Sorts a copy of in and checks that the result is sorted and a permutation
+// of in.
+func Check[T cmp.Ordered](sort func([]T), in []T) error {
+	out := slices.Clone(in)
+	sort(out)
+
+	if err := Sorted(out, cmp.Compare[T]); err != nil {
+		return err
+	}
+
+	return Permutation(in, out)
+}
+
+// Like Check, but sorts items with the given keys and also checks stability.
+func CheckStable(sort func([]Item), keys []int) error {
+	in := NewItems(keys)
+	out := slices.Clone(in)
+	sort(out)
+
+	if err := Sorted(out, CompareItems); err != nil {
+		return err
+	}
+
+	if err := Permutation(in, out); err != nil {
+		return err
+	}
+
+	return Stable(out)
+}
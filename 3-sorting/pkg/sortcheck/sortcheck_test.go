@@ -0,0 +1,45 @@
+package sortcheck
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckCatchesBrokenSorts(t *testing.T) {
+	in := []int{3, 1, 2, 1}
+
+	if err := Check(func([]int) {}, in); err == nil {
+		t.Error("unsorted output not detected")
+	}
+
+	if err := Check(func(arr []int) {
+		slices.Sort(arr)
+		arr[1] = arr[2]
+	}, in); err == nil {
+		t.Error("lost element not detected")
+	}
+
+	if err := CheckStable(func(items []Item) {
+		slices.SortFunc(items, func(a, b Item) int {
+			if c := CompareItems(a, b); c != 0 {
+				return c
+			}
+
+			return b.Idx - a.Idx
+		})
+	}, in); err == nil {
+		t.Error("unstable order not detected")
+	}
+}
+
+func TestGeneratorsRespectLimit(t *testing.T) {
+	rng := newRand()
+
+	for name, gen := range IntGenerators(10) {
+		for _, v := range gen(rng, 100) {
+			if v < -10 || v > 10 {
+				t.Errorf("%s generated %d outside [-10, 10]", name, v)
+			}
+		}
+	}
+}
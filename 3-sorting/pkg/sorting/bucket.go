@@ -0,0 +1,73 @@
+package sorting
+
+func BucketSort[F Float](arr []F) {
+	BucketSortFunc(arr, func(v F) F { return v })
+}
+
+// Stable bucket sort of arr by a float key. The range between the smallest and
+// largest finite key is split into len(arr) equal buckets, each of which is
+// insertion sorted, so uniformly distributed keys sort in O(n) on average.
+// Infinities go into the outermost buckets. NaN keys are not supported.
+func BucketSortFunc[E any, F Float](arr []E, key func(E) F) {
+	n := len(arr)
+
+	if n < 2 {
+		return
+	}
+
+	keys := make([]F, n)
+	lo, hi := F(0), F(0)
+	found := false
+
+	for i, v := range arr {
+		k := key(v)
+		keys[i] = k
+
+		if k-k != 0 {
+			// Infinite, doesn't define the bucket range
+			continue
+		}
+
+		if !found || k < lo {
+			lo = k
+		}
+
+		if !found || k > hi {
+			hi = k
+		}
+
+		found = true
+	}
+
+	buckets := make([][]int, n)
+
+	for i, k := range keys {
+		var b int
+
+		if k <= lo {
+			b = 0
+		} else if k >= hi {
+			b = n - 1
+		} else {
+			b = int(float64(k-lo) / float64(hi-lo) * float64(n-1))
+		}
+
+		buckets[b] = append(buckets[b], i)
+	}
+
+	sorted := make([]E, 0, n)
+
+	for _, bucket := range buckets {
+		for i := 1; i < len(bucket); i++ {
+			for j := i; j > 0 && keys[bucket[j]] < keys[bucket[j-1]]; j-- {
+				bucket[j], bucket[j-1] = bucket[j-1], bucket[j]
+			}
+		}
+
+		for _, idx := range bucket {
+			sorted = append(sorted, arr[idx])
+		}
+	}
+
+	copy(arr, sorted)
+}
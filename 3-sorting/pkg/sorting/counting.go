@@ -0,0 +1,88 @@
+package sorting
+
+import "fmt"
+
+// Counting sort allocates one counter per value in [min, max], so it refuses
+// ranges wider than this.
+const MaxCountingRange = 1 << 26
+
+// Returns the smallest key and the number of counters needed to cover all keys.
+func keyRange(keys []uint64) (uint64, int) {
+	lo, hi := keys[0], keys[0]
+
+	for _, k := range keys {
+		lo = min(lo, k)
+		hi = max(hi, k)
+	}
+
+	if hi-lo >= MaxCountingRange {
+		panic(fmt.Sprintf("counting sort range too large (%d values)", hi-lo+1))
+	}
+
+	return lo, int(hi-lo) + 1
+}
+
+// Sorts arr by counting occurrences of each value. Runs in O(n + k) time and
+// O(k) memory, where k is the difference between the largest and smallest
+// value, and panics if k exceeds MaxCountingRange.
+func CountingSort[T Integer](arr []T) {
+	if len(arr) < 2 {
+		return
+	}
+
+	width, signed := intInfo[T]()
+	keys := make([]uint64, len(arr))
+
+	for i, v := range arr {
+		keys[i] = radixKey(v, width, signed)
+	}
+
+	lo, k := keyRange(keys)
+	count := make([]int, k)
+	vals := make([]T, k)
+
+	for i, key := range keys {
+		count[key-lo]++
+		vals[key-lo] = arr[i]
+	}
+
+	i := 0
+
+	for v, c := range count {
+		for ; c > 0; c-- {
+			arr[i] = vals[v]
+			i++
+		}
+	}
+}
+
+// Stable counting sort of arr by key, with the same range limit as
+// CountingSort.
+func CountingSortFunc[E any, K Integer](arr []E, key func(E) K) {
+	n := len(arr)
+
+	if n < 2 {
+		return
+	}
+
+	keys, _ := radixKeys(arr, key)
+	lo, k := keyRange(keys)
+	count := make([]int, k+1)
+
+	for _, key := range keys {
+		count[key-lo+1]++
+	}
+
+	for v := 0; v < k; v++ {
+		count[v+1] += count[v]
+	}
+
+	aux := make([]E, n)
+
+	for i, key := range keys {
+		aux[count[key-lo]] = arr[i]
+		count[key-lo]++
+	}
+
+	copy(arr, aux)
+}
@@ -0,0 +1,91 @@
+package sorting
+
+import "runtime"
+
+// Work done by a sort.
+type Stats struct {
+	Comparisons int64
+	Swaps       int64
+	// Element writes that aren't part of a swap, e.g. merging into an
+	// auxiliary array
+	Moves    int64
+	MaxDepth int
+	// Bytes allocated on the heap while tracked
+	Bytes uint64
+
+	depth int
+}
+
+// Runs sort and adds the bytes it allocated to s.Bytes.
+func (s *Stats) Track(sort func()) {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	sort()
+	runtime.ReadMemStats(&after)
+
+	s.Bytes += after.TotalAlloc - before.TotalAlloc
+}
+
+// Comparator wraps a comparison function for the Func sorts. If Stats is set,
+// the comparisons, swaps, moves and recursion depth of the sort are counted in
+// it.
+type Comparator[T any] struct {
+	Cmp   func(a, b T) int
+	Stats *Stats
+}
+
+func NewComparator[T any](cmp func(a, b T) int) Comparator[T] {
+	return Comparator[T]{Cmp: cmp}
+}
+
+func Instrument[T any](cmp func(a, b T) int, stats *Stats) Comparator[T] {
+	return Comparator[T]{Cmp: cmp, Stats: stats}
+}
+
+func (c Comparator[T]) Compare(a, b T) int {
+	if c.Stats != nil {
+		c.Stats.Comparisons++
+	}
+
+	return c.Cmp(a, b)
+}
+
+func (c Comparator[T]) Less(a, b T) bool {
+	return c.Compare(a, b) < 0
+}
+
+func (c Comparator[T]) Swap(arr []T, i, j int) {
+	c.SwapValues(&arr[i], &arr[j])
+}
+
+// Swaps the values a and b point to, for sorts of non-slice containers.
+func (c Comparator[T]) SwapValues(a, b *T) {
+	if c.Stats != nil {
+		c.Stats.Swaps++
+	}
+
+	*a, *b = *b, *a
+}
+
+// Counts n element moves.
+func (c Comparator[T]) Move(n int) {
+	if c.Stats != nil {
+		c.Stats.Moves += int64(n)
+	}
+}
+
+// Marks entering a recursive call.
+func (c Comparator[T]) Enter() {
+	if c.Stats != nil {
+		c.Stats.depth++
+		c.Stats.MaxDepth = max(c.Stats.MaxDepth, c.Stats.depth)
+	}
+}
+
+// Marks returning from a recursive call.
+func (c Comparator[T]) Exit() {
+	if c.Stats != nil {
+		c.Stats.depth--
+	}
+}
@@ -0,0 +1,299 @@
+package sorting
+
+import "unsafe"
+
+// Below this many elements the MSD sorts fall back to insertion sort, as the
+// 256 counters per byte dominate for small partitions.
+const msdCutoff = 32
+
+type ByteString interface {
+	~string | ~[]byte
+}
+
+// Returns the width of K in bytes and whether it is signed.
+func intInfo[K Integer]() (uint, bool) {
+	var zero K
+	return uint(unsafe.Sizeof(zero)), ^zero < zero
+}
+
+// Maps v to an unsigned key with the same ordering. Signed values get their
+// sign bit flipped so negative numbers sort before positive ones.
+func radixKey[K Integer](v K, width uint, signed bool) uint64 {
+	key := uint64(v)
+
+	if width < 8 {
+		key &= 1<<(8*width) - 1
+	}
+
+	if signed {
+		key ^= 1 << (8*width - 1)
+	}
+
+	return key
+}
+
+func radixKeys[E any, K Integer](arr []E, key func(E) K) ([]uint64, uint) {
+	width, signed := intInfo[K]()
+	keys := make([]uint64, len(arr))
+
+	for i, v := range arr {
+		keys[i] = radixKey(key(v), width, signed)
+	}
+
+	return keys, width
+}
+
+func RadixSortLSD[T Integer](arr []T) {
+	RadixSortLSDFunc(arr, func(v T) T { return v })
+}
+
+// Stable least significant digit radix sort of arr by key, one byte per pass.
+// Passes where every key has the same byte are skipped.
+func RadixSortLSDFunc[E any, K Integer](arr []E, key func(E) K) {
+	n := len(arr)
+
+	if n < 2 {
+		return
+	}
+
+	keys, width := radixKeys(arr, key)
+	src, dst := arr, make([]E, n)
+	srcKeys, dstKeys := keys, make([]uint64, n)
+
+	for shift := uint(0); shift < 8*width; shift += 8 {
+		var count [257]int
+
+		for _, k := range srcKeys {
+			count[(k>>shift)&0xff+1]++
+		}
+
+		if count[(srcKeys[0]>>shift)&0xff+1] == n {
+			continue
+		}
+
+		for b := 0; b < 256; b++ {
+			count[b+1] += count[b]
+		}
+
+		for i, k := range srcKeys {
+			b := (k >> shift) & 0xff
+			dst[count[b]] = src[i]
+			dstKeys[count[b]] = k
+			count[b]++
+		}
+
+		src, dst = dst, src
+		srcKeys, dstKeys = dstKeys, srcKeys
+	}
+
+	if &src[0] != &arr[0] {
+		copy(arr, src)
+	}
+}
+
+func RadixSortMSD[T Integer](arr []T) {
+	RadixSortMSDFunc(arr, func(v T) T { return v })
+}
+
+// Stable most significant digit radix sort of arr by key, recursing into each
+// byte bucket.
+func RadixSortMSDFunc[E any, K Integer](arr []E, key func(E) K) {
+	n := len(arr)
+
+	if n < 2 {
+		return
+	}
+
+	keys, width := radixKeys(arr, key)
+	radixMSD(arr, keys, make([]E, n), make([]uint64, n), 8*(width-1))
+}
+
+func radixMSD[E any](arr []E, keys []uint64, aux []E, auxKeys []uint64, shift uint) {
+	n := len(arr)
+
+	if n <= msdCutoff {
+		insertionSortKeys(arr, keys)
+		return
+	}
+
+	var count [257]int
+
+	for _, k := range keys {
+		count[(k>>shift)&0xff+1]++
+	}
+
+	for b := 0; b < 256; b++ {
+		count[b+1] += count[b]
+	}
+
+	starts := count
+
+	for i, k := range keys {
+		b := (k >> shift) & 0xff
+		aux[count[b]] = arr[i]
+		auxKeys[count[b]] = k
+		count[b]++
+	}
+
+	copy(arr, aux)
+	copy(keys, auxKeys)
+
+	if shift == 0 {
+		return
+	}
+
+	for b := 0; b < 256; b++ {
+		lo, hi := starts[b], starts[b+1]
+
+		if hi-lo > 1 {
+			radixMSD(arr[lo:hi], keys[lo:hi], aux[lo:hi], auxKeys[lo:hi], shift-8)
+		}
+	}
+}
+
+func insertionSortKeys[E any](arr []E, keys []uint64) {
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0 && keys[j] < keys[j-1]; j-- {
+			arr[j], arr[j-1] = arr[j-1], arr[j]
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+}
+
+// Returns the bucket of s at position d. Strings shorter than d+1 go to bucket
+// 0 so that prefixes sort before longer strings.
+func charAt[S ByteString](s S, d int) int {
+	if d < len(s) {
+		return int(s[d]) + 1
+	}
+
+	return 0
+}
+
+func stringKeys[E any, S ByteString](arr []E, key func(E) S) []S {
+	keys := make([]S, len(arr))
+
+	for i, v := range arr {
+		keys[i] = key(v)
+	}
+
+	return keys
+}
+
+func RadixSortLSDStrings[S ByteString](arr []S) {
+	RadixSortLSDStringsFunc(arr, func(s S) S { return s })
+}
+
+// Stable LSD radix sort of arr by a string key. Keys of differing lengths are
+// handled by treating missing characters as smaller than any byte, so the
+// number of passes equals the longest key.
+func RadixSortLSDStringsFunc[E any, S ByteString](arr []E, key func(E) S) {
+	n := len(arr)
+
+	if n < 2 {
+		return
+	}
+
+	keys := stringKeys(arr, key)
+	maxLen := 0
+
+	for _, k := range keys {
+		maxLen = max(maxLen, len(k))
+	}
+
+	src, dst := arr, make([]E, n)
+	srcKeys, dstKeys := keys, make([]S, n)
+
+	for d := maxLen - 1; d >= 0; d-- {
+		var count [258]int
+
+		for _, k := range srcKeys {
+			count[charAt(k, d)+1]++
+		}
+
+		for b := 0; b < 257; b++ {
+			count[b+1] += count[b]
+		}
+
+		for i, k := range srcKeys {
+			b := charAt(k, d)
+			dst[count[b]] = src[i]
+			dstKeys[count[b]] = k
+			count[b]++
+		}
+
+		src, dst = dst, src
+		srcKeys, dstKeys = dstKeys, srcKeys
+	}
+
+	if &src[0] != &arr[0] {
+		copy(arr, src)
+	}
+}
+
+func RadixSortMSDStrings[S ByteString](arr []S) {
+	RadixSortMSDStringsFunc(arr, func(s S) S { return s })
+}
+
+// Stable MSD radix sort of arr by a string key. Only the characters needed to
+// tell the keys apart are examined.
+func RadixSortMSDStringsFunc[E any, S ByteString](arr []E, key func(E) S) {
+	n := len(arr)
+
+	if n < 2 {
+		return
+	}
+
+	keys := stringKeys(arr, key)
+	radixMSDStrings(arr, keys, make([]E, n), make([]S, n), 0)
+}
+
+func radixMSDStrings[E any, S ByteString](arr []E, keys []S, aux []E, auxKeys []S, d int) {
+	n := len(arr)
+
+	if n <= msdCutoff {
+		insertionSortStrings(arr, keys, d)
+		return
+	}
+
+	var count [258]int
+
+	for _, k := range keys {
+		count[charAt(k, d)+1]++
+	}
+
+	for b := 0; b < 257; b++ {
+		count[b+1] += count[b]
+	}
+
+	starts := count
+
+	for i, k := range keys {
+		b := charAt(k, d)
+		aux[count[b]] = arr[i]
+		auxKeys[count[b]] = k
+		count[b]++
+	}
+
+	copy(arr, aux)
+	copy(keys, auxKeys)
+
+	// Bucket 0 holds keys that ended at d and are therefore already in place
+	for b := 1; b < 257; b++ {
+		lo, hi := starts[b], starts[b+1]
+
+		if hi-lo > 1 {
+			radixMSDStrings(arr[lo:hi], keys[lo:hi], aux[lo:hi], auxKeys[lo:hi], d+1)
+		}
+	}
+}
+
+// Insertion sort on keys that share their first d characters.
+func insertionSortStrings[E any, S ByteString](arr []E, keys []S, d int) {
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0 && string(keys[j][d:]) < string(keys[j-1][d:]); j-- {
+			arr[j], arr[j-1] = arr[j-1], arr[j]
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package sorting
+
+import "cmp"
+
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Integer interface {
+	Signed | Unsigned
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+func SelectionSort[T cmp.Ordered](arr []T) {
+	for i := 0; i < len(arr)-1; i++ {
+		candidate := i
+
+		for j := i + 1; j < len(arr); j++ {
+			if arr[j] < arr[candidate] {
+				candidate = j
+			}
+		}
+
+		arr[i], arr[candidate] = arr[candidate], arr[i]
+	}
+}
+
+func InsertionSort[T cmp.Ordered](arr []T) {
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0 && arr[j] < arr[j-1]; j-- {
+			arr[j], arr[j-1] = arr[j-1], arr[j]
+		}
+	}
+}
+
+func MergeSort[T cmp.Ordered](arr []T) {
+	if len(arr) < 2 {
+		return
+	}
+
+	aux := make([]T, len(arr))
+	mergeSort(arr, aux, 0, len(arr)-1)
+}
+
+func mergeSort[T cmp.Ordered](org, aux []T, lo, hi int) {
+	if lo >= hi {
+		return
+	}
+
+	mid := (lo + hi) / 2
+
+	mergeSort(org, aux, lo, mid)
+	mergeSort(org, aux, mid+1, hi)
+
+	copy(aux[lo:hi+1], org[lo:hi+1])
+	merge(aux, org, lo, mid, hi)
+}
+
+// Merge sort that alternates the roles of the original and auxiliary arrays
+// at each level instead of copying into the auxiliary array before every merge.
+func MergeSort2[T cmp.Ordered](arr []T) {
+	if len(arr) < 2 {
+		return
+	}
+
+	aux := make([]T, len(arr))
+	copy(aux, arr)
+	mergeSort2(aux, arr, 0, len(arr)-1)
+}
+
+// Sorts src[lo..hi] into dst[lo..hi]. Both must hold the same elements on entry.
+func mergeSort2[T cmp.Ordered](src, dst []T, lo, hi int) {
+	if lo >= hi {
+		return
+	}
+
+	mid := (lo + hi) / 2
+
+	mergeSort2(dst, src, lo, mid)
+	mergeSort2(dst, src, mid+1, hi)
+	merge(src, dst, lo, mid, hi)
+}
+
+// Merges the sorted runs src[lo..mid] and src[mid+1..hi] into dst[lo..hi].
+func merge[T cmp.Ordered](src, dst []T, lo, mid, hi int) {
+	i := lo
+	j := mid + 1
+
+	for k := lo; k <= hi; k++ {
+		if i > mid {
+			dst[k] = src[j]
+			j++
+		} else if j > hi {
+			dst[k] = src[i]
+			i++
+		} else if src[j] < src[i] {
+			dst[k] = src[j]
+			j++
+		} else {
+			dst[k] = src[i]
+			i++
+		}
+	}
+}
+
+// The Func variants sort any element type through a Comparator, which also
+// lets them be instrumented. The plain variants above are kept separate so the
+// benchmarks don't pay for the indirect comparisons.
+
+func SelectionSortFunc[T any](arr []T, c Comparator[T]) {
+	for i := 0; i < len(arr)-1; i++ {
+		candidate := i
+
+		for j := i + 1; j < len(arr); j++ {
+			if c.Less(arr[j], arr[candidate]) {
+				candidate = j
+			}
+		}
+
+		c.Swap(arr, i, candidate)
+	}
+}
+
+func InsertionSortFunc[T any](arr []T, c Comparator[T]) {
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0 && c.Less(arr[j], arr[j-1]); j-- {
+			c.Swap(arr, j, j-1)
+		}
+	}
+}
+
+func MergeSortFunc[T any](arr []T, c Comparator[T]) {
+	if len(arr) < 2 {
+		return
+	}
+
+	aux := make([]T, len(arr))
+	mergeSortFunc(arr, aux, 0, len(arr)-1, c)
+}
+
+func mergeSortFunc[T any](org, aux []T, lo, hi int, c Comparator[T]) {
+	if lo >= hi {
+		return
+	}
+
+	c.Enter()
+	defer c.Exit()
+
+	mid := (lo + hi) / 2
+
+	mergeSortFunc(org, aux, lo, mid, c)
+	mergeSortFunc(org, aux, mid+1, hi, c)
+
+	copy(aux[lo:hi+1], org[lo:hi+1])
+	c.Move(hi - lo + 1)
+	mergeFunc(aux, org, lo, mid, hi, c)
+}
+
+func MergeSort2Func[T any](arr []T, c Comparator[T]) {
+	if len(arr) < 2 {
+		return
+	}
+
+	aux := make([]T, len(arr))
+	copy(aux, arr)
+	c.Move(len(arr))
+	mergeSort2Func(aux, arr, 0, len(arr)-1, c)
+}
+
+func mergeSort2Func[T any](src, dst []T, lo, hi int, c Comparator[T]) {
+	if lo >= hi {
+		return
+	}
+
+	c.Enter()
+	defer c.Exit()
+
+	mid := (lo + hi) / 2
+
+	mergeSort2Func(dst, src, lo, mid, c)
+	mergeSort2Func(dst, src, mid+1, hi, c)
+	mergeFunc(src, dst, lo, mid, hi, c)
+}
+
+func mergeFunc[T any](src, dst []T, lo, mid, hi int, c Comparator[T]) {
+	i := lo
+	j := mid + 1
+
+	for k := lo; k <= hi; k++ {
+		if i > mid {
+			dst[k] = src[j]
+			j++
+		} else if j > hi {
+			dst[k] = src[i]
+			i++
+		} else if c.Less(src[j], src[i]) {
+			dst[k] = src[j]
+			j++
+		} else {
+			dst[k] = src[i]
+			i++
+		}
+	}
+
+	c.Move(hi - lo + 1)
+}
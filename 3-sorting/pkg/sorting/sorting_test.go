@@ -0,0 +1,146 @@
+package sorting
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/phanty133/id1021/3-sorted/pkg/sortcheck"
+)
+
+func intFunc(sort func([]int, Comparator[int])) func([]int) {
+	return func(arr []int) {
+		sort(arr, NewComparator(cmp.Compare[int]))
+	}
+}
+
+func itemFunc(sort func([]sortcheck.Item, Comparator[sortcheck.Item])) func([]sortcheck.Item) {
+	return func(items []sortcheck.Item) {
+		sort(items, NewComparator(sortcheck.CompareItems))
+	}
+}
+
+func itemKey(i sortcheck.Item) int {
+	return i.Key
+}
+
+var intSorts = map[string]func([]int){
+	"Selection":     SelectionSort[int],
+	"Insertion":     InsertionSort[int],
+	"Merge":         MergeSort[int],
+	"Merge2":        MergeSort2[int],
+	"SelectionFunc": intFunc(SelectionSortFunc[int]),
+	"InsertionFunc": intFunc(InsertionSortFunc[int]),
+	"MergeFunc":     intFunc(MergeSortFunc[int]),
+	"Merge2Func":    intFunc(MergeSort2Func[int]),
+	"RadixLSD":      RadixSortLSD[int],
+	"RadixMSD":      RadixSortMSD[int],
+}
+
+var stableSorts = map[string]func([]sortcheck.Item){
+	"InsertionFunc": itemFunc(InsertionSortFunc[sortcheck.Item]),
+	"MergeFunc":     itemFunc(MergeSortFunc[sortcheck.Item]),
+	"Merge2Func":    itemFunc(MergeSort2Func[sortcheck.Item]),
+	"RadixLSDFunc": func(items []sortcheck.Item) {
+		RadixSortLSDFunc(items, itemKey)
+	},
+	"RadixMSDFunc": func(items []sortcheck.Item) {
+		RadixSortMSDFunc(items, itemKey)
+	},
+	"CountingFunc": func(items []sortcheck.Item) {
+		CountingSortFunc(items, itemKey)
+	},
+	"BucketFunc": func(items []sortcheck.Item) {
+		BucketSortFunc(items, func(i sortcheck.Item) float64 { return float64(i.Key) })
+	},
+}
+
+func TestIntSorts(t *testing.T) {
+	for name, sort := range intSorts {
+		t.Run(name, func(t *testing.T) {
+			sortcheck.Run(t, sort, sortcheck.IntGenerators(0))
+		})
+	}
+}
+
+func TestStableSorts(t *testing.T) {
+	for name, sort := range stableSorts {
+		t.Run(name, func(t *testing.T) {
+			sortcheck.RunStable(t, sort, sortcheck.IntGenerators(1<<20))
+		})
+	}
+}
+
+func TestCountingSort(t *testing.T) {
+	sortcheck.Run(t, CountingSort[int], sortcheck.IntGenerators(1<<20))
+}
+
+func TestNarrowIntRadixSorts(t *testing.T) {
+	sortcheck.Run(t, func(arr []int) {
+		narrow := make([]int8, len(arr))
+
+		for i, v := range arr {
+			narrow[i] = int8(v)
+		}
+
+		RadixSortLSD(narrow)
+
+		for i, v := range narrow {
+			arr[i] = int(v)
+		}
+	}, sortcheck.IntGenerators(127))
+
+	sortcheck.Run(t, func(arr []int) {
+		narrow := make([]uint16, len(arr))
+
+		for i, v := range arr {
+			narrow[i] = uint16(v + 1<<15)
+		}
+
+		RadixSortMSD(narrow)
+
+		for i, v := range narrow {
+			arr[i] = int(v) - 1<<15
+		}
+	}, sortcheck.IntGenerators(1<<15-1))
+}
+
+func TestStringRadixSorts(t *testing.T) {
+	sortcheck.Run(t, RadixSortLSDStrings[string], sortcheck.StringGenerators())
+	sortcheck.Run(t, RadixSortMSDStrings[string], sortcheck.StringGenerators())
+}
+
+func TestBucketSort(t *testing.T) {
+	sortcheck.Run(t, BucketSort[float64], sortcheck.FloatGenerators())
+}
+
+func TestStatsCount(t *testing.T) {
+	stats := Stats{}
+	arr := []int{3, 2, 1}
+
+	InsertionSortFunc(arr, Instrument(cmp.Compare[int], &stats))
+
+	if stats.Comparisons != 3 || stats.Swaps != 3 {
+		t.Errorf("got %d comparisons and %d swaps, want 3 and 3", stats.Comparisons, stats.Swaps)
+	}
+
+	stats = Stats{}
+	MergeSortFunc(make([]int, 8), Instrument(cmp.Compare[int], &stats))
+
+	if stats.MaxDepth != 3 {
+		t.Errorf("got max depth %d, want 3", stats.MaxDepth)
+	}
+}
+
+func FuzzIntSorts(f *testing.F) {
+	sorts := map[string]func([]int){"Counting": CountingSort[int]}
+
+	for name, sort := range intSorts {
+		sorts[name] = sort
+	}
+
+	sortcheck.Fuzz(f, sorts)
+}
+
+func FuzzStableSorts(f *testing.F) {
+	sortcheck.FuzzStable(f, stableSorts)
+}
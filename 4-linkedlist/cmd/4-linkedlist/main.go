@@ -7,16 +7,27 @@ import (
 	"os"
 	"time"
 
+	"github.com/phanty133/id1021/4-linkedlist/pkg/alist"
 	"github.com/phanty133/id1021/4-linkedlist/pkg/dllist"
-	"github.com/phanty133/id1021/4-linkedlist/pkg/llist"
+	"github.com/phanty133/id1021/4-linkedlist/pkg/ilist"
+	"github.com/phanty133/id1021/4-linkedlist/pkg/xlist"
+	"github.com/phanty133/id1021/containers/llist"
+	"github.com/phanty133/id1021/containers/ulist"
 )
 
 /*
 Benchmarks:
 - Append LL vs array (Vary initial size of list A, append it to fixed size list B, then switch)
 - DLL unlink vs LL unlink for K random cells, vary list length
+- Unrolled list in both, unlinking by index instead of by cell
+- Intrusive, arena and XOR lists in unlink/insert
 */
 
+type Elem struct {
+	ilist.Links[Elem]
+	Val int
+}
+
 func PrepareLLData(n int) (*llist.LinkedList[int], []*llist.LinkedListItem[int]) {
 	l := llist.New[int]()
 	items := make([]*llist.LinkedListItem[int], n)
@@ -41,6 +52,50 @@ func PrepareDLLData(n int) (*dllist.DoublyLinkedList[int], []*dllist.DoublyLinke
 	return l, items
 }
 
+func PrepareILData(n int) (*ilist.IntrusiveList[Elem, *Elem], []*Elem) {
+	l := ilist.New[Elem]()
+	items := make([]*Elem, n)
+
+	for i := 0; i < n; i++ {
+		items[i] = &Elem{Val: i}
+		l.PushFront(items[i])
+	}
+
+	return l, items
+}
+
+func PrepareALData(n int) (*alist.ArenaList[int], []int) {
+	l := alist.NewWithCapacity[int](n)
+	items := make([]int, n)
+
+	for i := 0; i < n; i++ {
+		items[i] = l.Add(i)
+	}
+
+	return l, items
+}
+
+func PrepareXLData(n int) (*xlist.XORList[int], []int) {
+	l := xlist.NewWithCapacity[int](n)
+	items := make([]int, n)
+
+	for i := 0; i < n; i++ {
+		items[i] = l.Add(i)
+	}
+
+	return l, items
+}
+
+func PrepareULData(n int) *ulist.UnrolledList[int] {
+	l := ulist.New[int]()
+
+	for i := 0; i < n; i++ {
+		l.Append(i)
+	}
+
+	return l
+}
+
 func PrepareArrayData(n int) []int {
 	a := make([]int, n)
 
@@ -73,6 +128,12 @@ func main() {
 	arrTimes2 := make([][]int, len(dynSize))
 	llTimes3 := make([][]int, len(dynSize))
 	dllTimes3 := make([][]int, len(dynSize))
+	ulTimes1 := make([][]int, len(dynSize))
+	ulTimes2 := make([][]int, len(dynSize))
+	ulTimes3 := make([][]int, len(dynSize))
+	ilTimes3 := make([][]int, len(dynSize))
+	alTimes3 := make([][]int, len(dynSize))
+	xlTimes3 := make([][]int, len(dynSize))
 
 	fmt.Println("Appending to linked list vs array")
 	fmt.Println("n\tllist\tarray")
@@ -113,6 +174,23 @@ func main() {
 			arrTimes1[sizeIdx][i] = int(time.Since(start).Microseconds())
 			fmt.Printf("%d\n", i)
 		}
+
+		fmt.Println("UnrolledList")
+
+		ulTimes1[sizeIdx] = make([]int, repeats)
+		for i := 0; i < repeats; i++ {
+			a := PrepareULData(n)
+			b := PrepareULData(fixedSize)
+
+			start := time.Now()
+
+			for v := range a.All() {
+				b.Append(v)
+			}
+
+			ulTimes1[sizeIdx][i] = int(time.Since(start).Microseconds())
+			fmt.Printf("%d\n", i)
+		}
 	}
 
 	for sizeIdx, n := range dynSize {
@@ -150,6 +228,23 @@ func main() {
 			arrTimes2[sizeIdx][i] = int(time.Since(start).Microseconds())
 			fmt.Printf("%d\n", i)
 		}
+
+		fmt.Println("UnrolledList")
+
+		ulTimes2[sizeIdx] = make([]int, repeats)
+		for i := 0; i < repeats; i++ {
+			a := PrepareULData(fixedSize)
+			b := PrepareULData(n)
+
+			start := time.Now()
+
+			for v := range a.All() {
+				b.Append(v)
+			}
+
+			ulTimes2[sizeIdx][i] = int(time.Since(start).Microseconds())
+			fmt.Printf("%d\n", i)
+		}
 	}
 
 	repeats2 := 500
@@ -201,6 +296,89 @@ func main() {
 			dllTimes3[sizeIdx][i] = int(time.Since(start).Microseconds())
 			fmt.Printf("%d\n", i)
 		}
+
+		fmt.Println("UnrolledList")
+
+		ulTimes3[sizeIdx] = make([]int, repeats2)
+		for i := 0; i < repeats2; i++ {
+			a := PrepareULData(n)
+
+			start := time.Now()
+
+			for _, idx := range kIdxs {
+				v, _ := a.RemoveAt(idx)
+				a.Add(v)
+			}
+
+			ulTimes3[sizeIdx][i] = int(time.Since(start).Microseconds())
+			fmt.Printf("%d\n", i)
+		}
+
+		fmt.Println("IntrusiveList")
+
+		ilTimes3[sizeIdx] = make([]int, repeats2)
+		for i := 0; i < repeats2; i++ {
+			a, items := PrepareILData(n)
+			kItems := make([]*Elem, k)
+
+			for j := 0; j < k; j++ {
+				kItems[j] = items[kIdxs[j]]
+			}
+
+			start := time.Now()
+
+			for _, item := range kItems {
+				a.Unlink(item)
+				a.Insert(item)
+			}
+
+			ilTimes3[sizeIdx][i] = int(time.Since(start).Microseconds())
+			fmt.Printf("%d\n", i)
+		}
+
+		fmt.Println("ArenaList")
+
+		alTimes3[sizeIdx] = make([]int, repeats2)
+		for i := 0; i < repeats2; i++ {
+			a, items := PrepareALData(n)
+			kItems := make([]int, k)
+
+			for j := 0; j < k; j++ {
+				kItems[j] = items[kIdxs[j]]
+			}
+
+			start := time.Now()
+
+			for _, item := range kItems {
+				a.Unlink(item)
+				a.Insert(item)
+			}
+
+			alTimes3[sizeIdx][i] = int(time.Since(start).Microseconds())
+			fmt.Printf("%d\n", i)
+		}
+
+		fmt.Println("XORList")
+
+		xlTimes3[sizeIdx] = make([]int, repeats2)
+		for i := 0; i < repeats2; i++ {
+			a, items := PrepareXLData(n)
+			kItems := make([]int, k)
+
+			for j := 0; j < k; j++ {
+				kItems[j] = items[kIdxs[j]]
+			}
+
+			start := time.Now()
+
+			for _, item := range kItems {
+				a.Unlink(item)
+				a.Insert(item)
+			}
+
+			xlTimes3[sizeIdx][i] = int(time.Since(start).Microseconds())
+			fmt.Printf("%d\n", i)
+		}
 	}
 
 	// Write the results to a CSV file
@@ -214,17 +392,26 @@ func main() {
 	writer := csv.NewWriter(csvFile)
 	defer writer.Flush()
 
-	writer.Write([]string{"", "10", "100", "1000", "5000", "10000", "15000", "10", "100", "1000", "5000", "10000", "15000"})
+	header := []string{""}
+
+	for i := 0; i < 6; i++ {
+		for _, n := range dynSize {
+			header = append(header, fmt.Sprintf("%d", n))
+		}
+	}
+
+	writer.Write(header)
 	writer.Write([]string{"Append to linked list vs array"})
-	writer.Write([]string{"n", "llist", "array"})
+	writer.Write([]string{"n", "llist", "array", "ulist"})
 
 	for i := 0; i < repeats; i++ {
-		row := make([]string, 2*len(dynSize)+1)
+		row := make([]string, 3*len(dynSize)+1)
 		row[0] = fmt.Sprintf("%d", i)
 
 		for j := 0; j < len(dynSize); j++ {
 			row[j+1] = fmt.Sprintf("%d", llTimes1[j][i])
 			row[j+len(dynSize)+1] = fmt.Sprintf("%d", arrTimes1[j][i])
+			row[j+2*len(dynSize)+1] = fmt.Sprintf("%d", ulTimes1[j][i])
 		}
 
 		writer.Write(row)
@@ -232,15 +419,16 @@ func main() {
 
 	writer.Write([]string{"\n"})
 	writer.Write([]string{"Append to linked list vs array"})
-	writer.Write([]string{"n", "llist", "array"})
+	writer.Write([]string{"n", "llist", "array", "ulist"})
 
 	for i := 0; i < repeats; i++ {
-		row := make([]string, 2*len(dynSize)+1)
+		row := make([]string, 3*len(dynSize)+1)
 		row[0] = fmt.Sprintf("%d", i)
 
 		for j := 0; j < len(dynSize); j++ {
 			row[j+1] = fmt.Sprintf("%d", llTimes2[j][i])
 			row[j+len(dynSize)+1] = fmt.Sprintf("%d", arrTimes2[j][i])
+			row[j+2*len(dynSize)+1] = fmt.Sprintf("%d", ulTimes2[j][i])
 		}
 
 		writer.Write(row)
@@ -248,15 +436,19 @@ func main() {
 
 	writer.Write([]string{"\n"})
 	writer.Write([]string{"unlink and insert"})
-	writer.Write([]string{"n", "llist", "dllist"})
+	writer.Write([]string{"n", "llist", "dllist", "ulist", "ilist", "alist", "xlist"})
 
 	for i := 0; i < repeats2; i++ {
-		row := make([]string, 2*len(dynSize)+1)
+		row := make([]string, 6*len(dynSize)+1)
 		row[0] = fmt.Sprintf("%d", i)
 
 		for j := 0; j < len(dynSize); j++ {
 			row[j+1] = fmt.Sprintf("%d", llTimes3[j][i])
 			row[j+len(dynSize)+1] = fmt.Sprintf("%d", dllTimes3[j][i])
+			row[j+2*len(dynSize)+1] = fmt.Sprintf("%d", ulTimes3[j][i])
+			row[j+3*len(dynSize)+1] = fmt.Sprintf("%d", ilTimes3[j][i])
+			row[j+4*len(dynSize)+1] = fmt.Sprintf("%d", alTimes3[j][i])
+			row[j+5*len(dynSize)+1] = fmt.Sprintf("%d", xlTimes3[j][i])
 		}
 
 		writer.Write(row)
@@ -0,0 +1,311 @@
+// Doubly linked list stored in a slice: cells refer to each other by index
+// instead of by pointer, so the garbage collector has a single object to scan
+// and cells are reused through a free list instead of being allocated.
+
+package alist
+
+import (
+	"errors"
+	"iter"
+)
+
+// Index returned when there is no cell.
+const None = -1
+
+const (
+	free = iota
+	unlinked
+	linked
+)
+
+type cell[T comparable] struct {
+	Head  T
+	next  int
+	prev  int
+	state uint8
+}
+
+type ArenaList[T comparable] struct {
+	cells  []cell[T]
+	first  int
+	last   int
+	free   int
+	length int
+}
+
+func New[T comparable]() *ArenaList[T] {
+	return NewWithCapacity[T](0)
+}
+
+// Creates a list with room for n cells before the arena has to grow.
+func NewWithCapacity[T comparable](n int) *ArenaList[T] {
+	return &ArenaList[T]{
+		cells: make([]cell[T], 0, n),
+		first: None,
+		last:  None,
+		free:  None,
+	}
+}
+
+func (l *ArenaList[T]) First() int {
+	return l.first
+}
+
+func (l *ArenaList[T]) Last() int {
+	return l.last
+}
+
+func (l *ArenaList[T]) Next(i int) int {
+	return l.cells[i].next
+}
+
+func (l *ArenaList[T]) Prev(i int) int {
+	return l.cells[i].prev
+}
+
+// Returns the value of cell i.
+func (l *ArenaList[T]) Get(i int) T {
+	return l.cells[i].Head
+}
+
+func (l *ArenaList[T]) Length() int {
+	return l.length
+}
+
+func (l *ArenaList[T]) Empty() bool {
+	return l.length == 0
+}
+
+func (l *ArenaList[T]) is(i int, state uint8) bool {
+	return i >= 0 && i < len(l.cells) && l.cells[i].state == state
+}
+
+// Returns an unlinked cell holding value, reusing a freed cell if possible.
+func (l *ArenaList[T]) alloc(value T) int {
+	c := cell[T]{Head: value, next: None, prev: None, state: unlinked}
+
+	if l.free == None {
+		l.cells = append(l.cells, c)
+		return len(l.cells) - 1
+	}
+
+	i := l.free
+	l.free = l.cells[i].next
+	l.cells[i] = c
+
+	return i
+}
+
+func (l *ArenaList[T]) release(i int) {
+	l.cells[i] = cell[T]{next: l.free, prev: None, state: free}
+	l.free = i
+}
+
+// Links cell i between prev and next, either of which may be None at the ends.
+func (l *ArenaList[T]) link(i, prev, next int) {
+	c := &l.cells[i]
+	c.prev = prev
+	c.next = next
+	c.state = linked
+
+	if prev != None {
+		l.cells[prev].next = i
+	} else {
+		l.first = i
+	}
+
+	if next != None {
+		l.cells[next].prev = i
+	} else {
+		l.last = i
+	}
+
+	l.length++
+}
+
+// Same as PushFront.
+func (l *ArenaList[T]) Add(value T) int {
+	return l.PushFront(value)
+}
+
+func (l *ArenaList[T]) PushFront(value T) int {
+	i := l.alloc(value)
+	l.link(i, None, l.first)
+
+	return i
+}
+
+func (l *ArenaList[T]) PushBack(value T) int {
+	i := l.alloc(value)
+	l.link(i, l.last, None)
+
+	return i
+}
+
+func (l *ArenaList[T]) PopFront() (T, error) {
+	if l.first == None {
+		var zero T
+		return zero, errors.New("list is empty")
+	}
+
+	i := l.first
+	value := l.cells[i].Head
+	l.Unlink(i)
+	l.release(i)
+
+	return value, nil
+}
+
+func (l *ArenaList[T]) PopBack() (T, error) {
+	if l.last == None {
+		var zero T
+		return zero, errors.New("list is empty")
+	}
+
+	i := l.last
+	value := l.cells[i].Head
+	l.Unlink(i)
+	l.release(i)
+
+	return value, nil
+}
+
+// Inserts value before mark, which must be linked.
+func (l *ArenaList[T]) InsertBefore(value T, mark int) int {
+	if !l.is(mark, linked) {
+		return None
+	}
+
+	i := l.alloc(value)
+	l.link(i, l.cells[mark].prev, mark)
+
+	return i
+}
+
+// Inserts value after mark, which must be linked.
+func (l *ArenaList[T]) InsertAfter(value T, mark int) int {
+	if !l.is(mark, linked) {
+		return None
+	}
+
+	i := l.alloc(value)
+	l.link(i, mark, l.cells[mark].next)
+
+	return i
+}
+
+func (l *ArenaList[T]) MoveToFront(i int) {
+	if !l.is(i, linked) || l.first == i {
+		return
+	}
+
+	l.Unlink(i)
+	l.link(i, None, l.first)
+}
+
+func (l *ArenaList[T]) MoveToBack(i int) {
+	if !l.is(i, linked) || l.last == i {
+		return
+	}
+
+	l.Unlink(i)
+	l.link(i, l.last, None)
+}
+
+func (l *ArenaList[T]) Find(value T) int {
+	for i := l.first; i != None; i = l.cells[i].next {
+		if l.cells[i].Head == value {
+			return i
+		}
+	}
+
+	return None
+}
+
+// Removes the first cell holding value and frees it.
+func (l *ArenaList[T]) Remove(value T) {
+	i := l.Find(value)
+
+	if i == None {
+		return
+	}
+
+	l.Unlink(i)
+	l.release(i)
+}
+
+// Removes cell i from the list but keeps it allocated, so it can be linked
+// again with Insert. Cells that aren't linked are ignored.
+func (l *ArenaList[T]) Unlink(i int) {
+	if !l.is(i, linked) {
+		return
+	}
+
+	c := &l.cells[i]
+
+	if c.prev != None {
+		l.cells[c.prev].next = c.next
+	} else {
+		l.first = c.next
+	}
+
+	if c.next != None {
+		l.cells[c.next].prev = c.prev
+	} else {
+		l.last = c.prev
+	}
+
+	c.next = None
+	c.prev = None
+	c.state = unlinked
+	l.length--
+}
+
+// Links an unlinked cell at the front of the list.
+func (l *ArenaList[T]) Insert(i int) {
+	if !l.is(i, unlinked) {
+		return
+	}
+
+	l.link(i, None, l.first)
+}
+
+// Frees an unlinked cell for reuse.
+func (l *ArenaList[T]) Free(i int) {
+	if !l.is(i, unlinked) {
+		return
+	}
+
+	l.release(i)
+}
+
+// Iterates over the values from first to last. The current cell may be
+// unlinked during iteration.
+func (l *ArenaList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := l.first; i != None; {
+			next := l.cells[i].next
+
+			if !yield(l.cells[i].Head) {
+				return
+			}
+
+			i = next
+		}
+	}
+}
+
+// Iterates over the values from last to first.
+func (l *ArenaList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := l.last; i != None; {
+			prev := l.cells[i].prev
+
+			if !yield(l.cells[i].Head) {
+				return
+			}
+
+			i = prev
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package alist
+
+import (
+	"testing"
+
+	"github.com/phanty133/id1021/4-linkedlist/pkg/listtest"
+)
+
+func TestSuite(t *testing.T) {
+	listtest.Run(t, New[int], None)
+}
+
+func TestReuse(t *testing.T) {
+	l := New[int]()
+	a := l.PushBack(1)
+	b := l.PushBack(2)
+	l.Remove(1)
+
+	// The freed cell is reused before the arena grows
+	if c := l.PushBack(3); c != a || len(l.cells) != 2 {
+		t.Errorf("got cell %d with %d cells, want %d with 2", c, len(l.cells), a)
+	}
+
+	// Unlinked cells stay allocated until freed
+	l.Unlink(b)
+
+	if l.Get(b) != 2 || l.PushFront(4) == b {
+		t.Error("unlinked cell was reused")
+	}
+
+	l.Free(b)
+	l.Insert(b)
+	listtest.Expect(t, listtest.List[int](l), None, 4, 3)
+
+	if l.PushBack(5) != b {
+		t.Error("freed cell wasn't reused")
+	}
+
+	if l.InsertAfter(0, 100) != None || l.InsertBefore(0, None) != None {
+		t.Error("insert accepted an invalid mark")
+	}
+
+	listtest.Expect(t, listtest.List[int](l), None, 4, 3, 5)
+}
@@ -1,14 +1,22 @@
 package dllist
 
+import (
+	"errors"
+	"iter"
+)
+
 type DoublyLinkedListItem[T comparable] struct {
 	Head T
 	next *DoublyLinkedListItem[T]
 	prev *DoublyLinkedListItem[T]
+	// The list the item is linked into, nil once unlinked
+	list *DoublyLinkedList[T]
 }
 
 type DoublyLinkedList[T comparable] struct {
-	first *DoublyLinkedListItem[T]
-	last  *DoublyLinkedListItem[T]
+	first  *DoublyLinkedListItem[T]
+	last   *DoublyLinkedListItem[T]
+	length int
 }
 
 func New[T comparable]() *DoublyLinkedList[T] {
@@ -31,62 +39,131 @@ func (l *DoublyLinkedListItem[T]) Prev() *DoublyLinkedListItem[T] {
 	return l.prev
 }
 
-func (l *DoublyLinkedList[T]) Add(value T) *DoublyLinkedListItem[T] {
-	next := l.first
-	item := &DoublyLinkedListItem[T]{Head: value, next: next}
+func (l *DoublyLinkedList[T]) Length() int {
+	return l.length
+}
+
+func (l *DoublyLinkedList[T]) Empty() bool {
+	return l.length == 0
+}
+
+// Links item between prev and next, either of which may be nil at the ends.
+func (l *DoublyLinkedList[T]) link(item, prev, next *DoublyLinkedListItem[T]) {
+	item.prev = prev
+	item.next = next
+	item.list = l
+
+	if prev != nil {
+		prev.next = item
+	} else {
+		l.first = item
+	}
 
 	if next != nil {
 		next.prev = item
+	} else {
+		l.last = item
 	}
 
-	l.first = item
+	l.length++
+}
 
-	if l.last == nil {
-		l.last = item
-	}
+// Same as PushFront.
+func (l *DoublyLinkedList[T]) Add(value T) *DoublyLinkedListItem[T] {
+	return l.PushFront(value)
+}
+
+func (l *DoublyLinkedList[T]) PushFront(value T) *DoublyLinkedListItem[T] {
+	item := &DoublyLinkedListItem[T]{Head: value}
+	l.link(item, nil, l.first)
 
 	return item
 }
 
-func (l *DoublyLinkedList[T]) Length() int {
+func (l *DoublyLinkedList[T]) PushBack(value T) *DoublyLinkedListItem[T] {
+	item := &DoublyLinkedListItem[T]{Head: value}
+	l.link(item, l.last, nil)
+
+	return item
+}
+
+func (l *DoublyLinkedList[T]) PopFront() (T, error) {
 	if l.first == nil {
-		return 0
+		var zero T
+		return zero, errors.New("list is empty")
 	}
 
 	item := l.first
-	length := 1
+	l.Unlink(item)
+
+	return item.Head, nil
+}
+
+func (l *DoublyLinkedList[T]) PopBack() (T, error) {
+	if l.last == nil {
+		var zero T
+		return zero, errors.New("list is empty")
+	}
 
-	for item.next != nil {
-		item = item.next
-		length++
+	item := l.last
+	l.Unlink(item)
+
+	return item.Head, nil
+}
+
+// Inserts value before mark, which must be in the list.
+func (l *DoublyLinkedList[T]) InsertBefore(value T, mark *DoublyLinkedListItem[T]) *DoublyLinkedListItem[T] {
+	if mark.list != l {
+		return nil
 	}
 
-	return length
+	item := &DoublyLinkedListItem[T]{Head: value}
+	l.link(item, mark.prev, mark)
+
+	return item
 }
 
-func (l *DoublyLinkedList[T]) Find(value T) *DoublyLinkedListItem[T] {
-	if l.first == nil {
+// Inserts value after mark, which must be in the list.
+func (l *DoublyLinkedList[T]) InsertAfter(value T, mark *DoublyLinkedListItem[T]) *DoublyLinkedListItem[T] {
+	if mark.list != l {
 		return nil
 	}
 
-	item := l.first
+	item := &DoublyLinkedListItem[T]{Head: value}
+	l.link(item, mark, mark.next)
 
-	for item.next != nil {
+	return item
+}
+
+func (l *DoublyLinkedList[T]) MoveToFront(item *DoublyLinkedListItem[T]) {
+	if item.list != l || l.first == item {
+		return
+	}
+
+	l.Unlink(item)
+	l.link(item, nil, l.first)
+}
+
+func (l *DoublyLinkedList[T]) MoveToBack(item *DoublyLinkedListItem[T]) {
+	if item.list != l || l.last == item {
+		return
+	}
+
+	l.Unlink(item)
+	l.link(item, l.last, nil)
+}
+
+func (l *DoublyLinkedList[T]) Find(value T) *DoublyLinkedListItem[T] {
+	for item := l.first; item != nil; item = item.next {
 		if item.Head == value {
 			return item
 		}
-
-		item = item.next
 	}
 
 	return nil
 }
 
 func (l *DoublyLinkedList[T]) Remove(value T) {
-	if l.first == nil {
-		return
-	}
-
 	item := l.Find(value)
 
 	if item == nil {
@@ -96,7 +173,12 @@ func (l *DoublyLinkedList[T]) Remove(value T) {
 	l.Unlink(item)
 }
 
+// Removes item from the list. Items that aren't in the list are ignored.
 func (l *DoublyLinkedList[T]) Unlink(item *DoublyLinkedListItem[T]) {
+	if item.list != l {
+		return
+	}
+
 	if item.prev != nil {
 		item.prev.next = item.next
 	} else {
@@ -108,10 +190,51 @@ func (l *DoublyLinkedList[T]) Unlink(item *DoublyLinkedListItem[T]) {
 	} else {
 		l.last = item.prev
 	}
+
+	item.next = nil
+	item.prev = nil
+	item.list = nil
+	l.length--
 }
 
+// Links an unlinked item at the front of the list.
 func (l *DoublyLinkedList[T]) Insert(item *DoublyLinkedListItem[T]) {
-	item.next = l.first
-	l.first.prev = item
-	l.first = item
+	if item.list != nil {
+		return
+	}
+
+	l.link(item, nil, l.first)
+}
+
+// Iterates over the values from first to last. The next item is read before
+// each value is yielded, so the loop body may remove the current value, e.g.
+// with Remove when the values are unique, without cutting the iteration short.
+func (l *DoublyLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := l.first; item != nil; {
+			// Read ahead so the loop body may remove the current value
+			next := item.next
+
+			if !yield(item.Head) {
+				return
+			}
+
+			item = next
+		}
+	}
+}
+
+// Iterates over the values from last to first.
+func (l *DoublyLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := l.last; item != nil; {
+			prev := item.prev
+
+			if !yield(item.Head) {
+				return
+			}
+
+			item = prev
+		}
+	}
 }
@@ -0,0 +1,86 @@
+package dllist
+
+import (
+	"testing"
+
+	"github.com/phanty133/id1021/4-linkedlist/pkg/listtest"
+)
+
+// Checks the values with listtest.Expect and that the ends aren't linked past
+// the end of the list.
+func expect(t *testing.T, l *DoublyLinkedList[int], want ...int) {
+	t.Helper()
+	listtest.Expect[*DoublyLinkedListItem[int]](t, l, nil, want...)
+
+	if len(want) > 0 && (l.First().Prev() != nil || l.Last().Next() != nil) {
+		t.Fatal("list ends are linked past the end")
+	}
+}
+
+func TestSuite(t *testing.T) {
+	listtest.Run(t, New[int], nil)
+}
+
+func TestLinks(t *testing.T) {
+	l := New[int]()
+	item := l.PushBack(1)
+	expect(t, l, 1)
+
+	l.Add(0)
+	l.PushBack(2)
+	expect(t, l, 0, 1, 2)
+
+	l.MoveToFront(item)
+	expect(t, l, 1, 0, 2)
+
+	l.MoveToBack(item)
+	expect(t, l, 0, 2, 1)
+
+	l.PopBack()
+	l.PopFront()
+	expect(t, l, 2)
+}
+
+func TestForeignItems(t *testing.T) {
+	l := New[int]()
+	item := l.PushBack(1)
+
+	// Unlinking from another list must not corrupt the length
+	New[int]().Unlink(item)
+	expect(t, l, 1)
+
+	// Items already in a list can't be inserted or used as marks elsewhere
+	other := New[int]()
+	other.Insert(item)
+
+	if l.InsertBefore(0, other.PushBack(3)) != nil {
+		t.Error("InsertBefore accepted a mark from another list")
+	}
+
+	expect(t, other, 3)
+	expect(t, l, 1)
+}
+
+func TestRemoveWhileIterating(t *testing.T) {
+	l := New[int]()
+
+	for i := 0; i < 6; i++ {
+		l.PushBack(i)
+	}
+
+	seen := 0
+
+	for v := range l.All() {
+		seen++
+
+		if v%2 == 0 {
+			l.Remove(v)
+		}
+	}
+
+	if seen != 6 {
+		t.Fatalf("iterated over %d values, want 6", seen)
+	}
+
+	expect(t, l, 1, 3, 5)
+}
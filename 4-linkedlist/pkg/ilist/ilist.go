@@ -0,0 +1,234 @@
+// Intrusive doubly linked list: the links live inside the elements, which
+// embed a Links field, so linking an element allocates nothing.
+//
+//	type Job struct {
+//		ilist.Links[Job]
+//		ID int
+//	}
+//
+//	l := ilist.New[Job]()
+//	l.PushBack(&Job{ID: 1})
+
+package ilist
+
+import (
+	"errors"
+	"iter"
+)
+
+type Links[E any] struct {
+	next *E
+	prev *E
+	// The list the element is linked into, nil once unlinked
+	list any
+}
+
+func (l *Links[E]) links() *Links[E] {
+	return l
+}
+
+// Satisfied by pointers to structs that embed Links.
+type Element[E any] interface {
+	*E
+	links() *Links[E]
+}
+
+type IntrusiveList[E any, P Element[E]] struct {
+	first  *E
+	last   *E
+	length int
+}
+
+func New[E any, P Element[E]]() *IntrusiveList[E, P] {
+	return &IntrusiveList[E, P]{}
+}
+
+func (l *IntrusiveList[E, P]) First() P {
+	return l.first
+}
+
+func (l *IntrusiveList[E, P]) Last() P {
+	return l.last
+}
+
+func (l *IntrusiveList[E, P]) Next(e P) P {
+	return e.links().next
+}
+
+func (l *IntrusiveList[E, P]) Prev(e P) P {
+	return e.links().prev
+}
+
+func (l *IntrusiveList[E, P]) Length() int {
+	return l.length
+}
+
+func (l *IntrusiveList[E, P]) Empty() bool {
+	return l.length == 0
+}
+
+// Returns true if e is linked into the list.
+func (l *IntrusiveList[E, P]) Contains(e P) bool {
+	return e.links().list == any(l)
+}
+
+// Links e between prev and next, either of which may be nil at the ends.
+func (l *IntrusiveList[E, P]) link(e, prev, next P) {
+	links := e.links()
+	links.prev = prev
+	links.next = next
+	links.list = l
+
+	if prev != nil {
+		prev.links().next = e
+	} else {
+		l.first = e
+	}
+
+	if next != nil {
+		next.links().prev = e
+	} else {
+		l.last = e
+	}
+
+	l.length++
+}
+
+// Links e at the front. Elements that are already in a list are ignored.
+func (l *IntrusiveList[E, P]) PushFront(e P) {
+	if e.links().list != nil {
+		return
+	}
+
+	l.link(e, nil, l.first)
+}
+
+func (l *IntrusiveList[E, P]) PushBack(e P) {
+	if e.links().list != nil {
+		return
+	}
+
+	l.link(e, l.last, nil)
+}
+
+func (l *IntrusiveList[E, P]) PopFront() (P, error) {
+	if l.first == nil {
+		return nil, errors.New("list is empty")
+	}
+
+	e := P(l.first)
+	l.Unlink(e)
+
+	return e, nil
+}
+
+func (l *IntrusiveList[E, P]) PopBack() (P, error) {
+	if l.last == nil {
+		return nil, errors.New("list is empty")
+	}
+
+	e := P(l.last)
+	l.Unlink(e)
+
+	return e, nil
+}
+
+// Links e before mark. Returns false if mark isn't in the list or e is
+// already in a list.
+func (l *IntrusiveList[E, P]) InsertBefore(e, mark P) bool {
+	if !l.Contains(mark) || e.links().list != nil {
+		return false
+	}
+
+	l.link(e, mark.links().prev, mark)
+	return true
+}
+
+// Links e after mark. Returns false if mark isn't in the list or e is already
+// in a list.
+func (l *IntrusiveList[E, P]) InsertAfter(e, mark P) bool {
+	if !l.Contains(mark) || e.links().list != nil {
+		return false
+	}
+
+	l.link(e, mark, mark.links().next)
+	return true
+}
+
+func (l *IntrusiveList[E, P]) MoveToFront(e P) {
+	if !l.Contains(e) || l.first == e {
+		return
+	}
+
+	l.Unlink(e)
+	l.link(e, nil, l.first)
+}
+
+func (l *IntrusiveList[E, P]) MoveToBack(e P) {
+	if !l.Contains(e) || l.last == e {
+		return
+	}
+
+	l.Unlink(e)
+	l.link(e, l.last, nil)
+}
+
+// Removes e from the list. Elements that aren't in the list are ignored.
+func (l *IntrusiveList[E, P]) Unlink(e P) {
+	if !l.Contains(e) {
+		return
+	}
+
+	links := e.links()
+
+	if links.prev != nil {
+		P(links.prev).links().next = links.next
+	} else {
+		l.first = links.next
+	}
+
+	if links.next != nil {
+		P(links.next).links().prev = links.prev
+	} else {
+		l.last = links.prev
+	}
+
+	*links = Links[E]{}
+	l.length--
+}
+
+// Same as PushFront.
+func (l *IntrusiveList[E, P]) Insert(e P) {
+	l.PushFront(e)
+}
+
+// Iterates over the elements from first to last. The current element may be
+// unlinked during iteration.
+func (l *IntrusiveList[E, P]) All() iter.Seq[P] {
+	return func(yield func(P) bool) {
+		for e := P(l.first); e != nil; {
+			next := P(e.links().next)
+
+			if !yield(e) {
+				return
+			}
+
+			e = next
+		}
+	}
+}
+
+// Iterates over the elements from last to first.
+func (l *IntrusiveList[E, P]) Backward() iter.Seq[P] {
+	return func(yield func(P) bool) {
+		for e := P(l.last); e != nil; {
+			prev := P(e.links().prev)
+
+			if !yield(e) {
+				return
+			}
+
+			e = prev
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package ilist
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/phanty133/id1021/4-linkedlist/pkg/listtest"
+)
+
+type elem struct {
+	Links[elem]
+	val int
+}
+
+// Adapts the list to the value based API of listtest.
+type intList struct {
+	*IntrusiveList[elem, *elem]
+}
+
+func newIntList() intList {
+	return intList{New[elem]()}
+}
+
+func (l intList) PushFront(value int) *elem {
+	e := &elem{val: value}
+	l.IntrusiveList.PushFront(e)
+	return e
+}
+
+func (l intList) PushBack(value int) *elem {
+	e := &elem{val: value}
+	l.IntrusiveList.PushBack(e)
+	return e
+}
+
+func (l intList) PopFront() (int, error) {
+	e, err := l.IntrusiveList.PopFront()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return e.val, nil
+}
+
+func (l intList) PopBack() (int, error) {
+	e, err := l.IntrusiveList.PopBack()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return e.val, nil
+}
+
+func (l intList) InsertBefore(value int, mark *elem) *elem {
+	e := &elem{val: value}
+
+	if !l.IntrusiveList.InsertBefore(e, mark) {
+		return nil
+	}
+
+	return e
+}
+
+func (l intList) InsertAfter(value int, mark *elem) *elem {
+	e := &elem{val: value}
+
+	if !l.IntrusiveList.InsertAfter(e, mark) {
+		return nil
+	}
+
+	return e
+}
+
+func (l intList) Find(value int) *elem {
+	for e := range l.IntrusiveList.All() {
+		if e.val == value {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (l intList) Remove(value int) {
+	if e := l.Find(value); e != nil {
+		l.Unlink(e)
+	}
+}
+
+func (l intList) All() iter.Seq[int] {
+	return values(l.IntrusiveList.All())
+}
+
+func (l intList) Backward() iter.Seq[int] {
+	return values(l.IntrusiveList.Backward())
+}
+
+func values(seq iter.Seq[*elem]) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for e := range seq {
+			if !yield(e.val) {
+				return
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	listtest.Run(t, newIntList, nil)
+}
+
+func TestForeignElements(t *testing.T) {
+	a, b := newIntList(), newIntList()
+	e := a.PushBack(1)
+	mark := b.PushBack(2)
+
+	// An element can only be in one list at a time
+	b.IntrusiveList.PushBack(e)
+	b.Unlink(e)
+
+	if b.IntrusiveList.InsertBefore(&elem{val: 0}, e) || b.IntrusiveList.InsertAfter(e, mark) {
+		t.Error("insert accepted an element or mark from another list")
+	}
+
+	listtest.Expect(t, listtest.List[*elem](a), nil, 1)
+	listtest.Expect(t, listtest.List[*elem](b), nil, 2)
+
+	if !a.Contains(e) || b.Contains(e) {
+		t.Error("Contains reports the wrong list")
+	}
+
+	if a.Next(e) != nil || a.Prev(e) != nil {
+		t.Error("single element is linked to something")
+	}
+}
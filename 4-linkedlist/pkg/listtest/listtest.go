@@ -0,0 +1,233 @@
+// Shared tests for the doubly linked list variants. A list is tested through
+// handles to its cells, which are pointers for dllist and ilist and indices for
+// alist and xlist.
+
+package listtest
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+type List[H comparable] interface {
+	First() H
+	Last() H
+	Length() int
+	Empty() bool
+	PushFront(value int) H
+	PushBack(value int) H
+	PopFront() (int, error)
+	PopBack() (int, error)
+	InsertBefore(value int, mark H) H
+	InsertAfter(value int, mark H) H
+	MoveToFront(h H)
+	MoveToBack(h H)
+	Find(value int) H
+	Remove(value int)
+	Unlink(h H)
+	Insert(h H)
+	All() iter.Seq[int]
+	Backward() iter.Seq[int]
+}
+
+// Checks the values in both directions, the cached length and the ends.
+func Expect[H comparable](t *testing.T, l List[H], none H, want ...int) {
+	t.Helper()
+
+	if got := slices.Collect(l.All()); !slices.Equal(got, want) {
+		t.Fatalf("forward: got %v, want %v", got, want)
+	}
+
+	backward := slices.Clone(want)
+	slices.Reverse(backward)
+
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, backward) {
+		t.Fatalf("backward: got %v, want %v", got, backward)
+	}
+
+	if l.Length() != len(want) {
+		t.Fatalf("length: got %d, want %d", l.Length(), len(want))
+	}
+
+	if l.Empty() != (len(want) == 0) {
+		t.Fatalf("empty: got %t with %d items", l.Empty(), len(want))
+	}
+
+	if (l.First() == none) != (len(want) == 0) || (l.Last() == none) != (len(want) == 0) {
+		t.Fatal("first or last item doesn't match the length")
+	}
+}
+
+// Runs the suite on lists from newList. none is the handle returned when there
+// is no cell, e.g. nil for pointers.
+func Run[H comparable, L List[H]](t *testing.T, newList func() L, none H) {
+	expect := func(t *testing.T, l L, want ...int) {
+		t.Helper()
+		Expect[H](t, l, none, want...)
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		l := newList()
+		expect(t, l)
+
+		if _, err := l.PopFront(); err == nil {
+			t.Error("PopFront on empty list didn't fail")
+		}
+
+		if _, err := l.PopBack(); err == nil {
+			t.Error("PopBack on empty list didn't fail")
+		}
+
+		if l.Find(0) != none {
+			t.Error("Find on empty list found an item")
+		}
+
+		l.Remove(0)
+		expect(t, l)
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		l := newList()
+		item := l.PushBack(1)
+		expect(t, l, 1)
+
+		if l.First() != item || l.Last() != item {
+			t.Fatal("single item isn't both first and last")
+		}
+
+		l.MoveToFront(item)
+		l.MoveToBack(item)
+		expect(t, l, 1)
+
+		if l.Find(1) != item {
+			t.Error("Find didn't find the only item")
+		}
+
+		if v, err := l.PopBack(); err != nil || v != 1 {
+			t.Errorf("PopBack: got %d, %v", v, err)
+		}
+
+		expect(t, l)
+
+		l.PushFront(2)
+
+		if v, err := l.PopFront(); err != nil || v != 2 {
+			t.Errorf("PopFront: got %d, %v", v, err)
+		}
+
+		expect(t, l)
+	})
+
+	t.Run("PushPop", func(t *testing.T) {
+		l := newList()
+		l.PushBack(2)
+		l.PushFront(1)
+		l.PushBack(3)
+		l.PushFront(0)
+		expect(t, l, 0, 1, 2, 3)
+
+		if v, _ := l.PopFront(); v != 0 {
+			t.Errorf("PopFront: got %d, want 0", v)
+		}
+
+		if v, _ := l.PopBack(); v != 3 {
+			t.Errorf("PopBack: got %d, want 3", v)
+		}
+
+		expect(t, l, 1, 2)
+	})
+
+	t.Run("InsertMove", func(t *testing.T) {
+		l := newList()
+		two := l.PushBack(2)
+		l.InsertBefore(1, two)
+		four := l.InsertAfter(4, two)
+		l.InsertAfter(3, two)
+		l.InsertBefore(0, l.First())
+		l.InsertAfter(5, four)
+		expect(t, l, 0, 1, 2, 3, 4, 5)
+
+		l.MoveToFront(four)
+		expect(t, l, 4, 0, 1, 2, 3, 5)
+
+		l.MoveToBack(two)
+		expect(t, l, 4, 0, 1, 3, 5, 2)
+
+		l.MoveToBack(two)
+		l.MoveToFront(four)
+		expect(t, l, 4, 0, 1, 3, 5, 2)
+	})
+
+	t.Run("FindRemoveLast", func(t *testing.T) {
+		l := newList()
+		l.PushBack(1)
+		last := l.PushBack(2)
+
+		if l.Find(2) != last {
+			t.Fatal("Find didn't find the last item")
+		}
+
+		l.Remove(2)
+		expect(t, l, 1)
+	})
+
+	t.Run("UnlinkInsert", func(t *testing.T) {
+		l := newList()
+		item := l.PushBack(1)
+		l.Unlink(item)
+		expect(t, l)
+
+		// Unlinking twice must not corrupt the length
+		l.Unlink(item)
+		l.PushBack(2)
+		expect(t, l, 2)
+
+		l.Remove(2)
+		l.Insert(item)
+		expect(t, l, 1)
+
+		// Inserting a linked item again must not create a cycle
+		l.Insert(item)
+		expect(t, l, 1)
+	})
+
+	t.Run("UnlinkAll", func(t *testing.T) {
+		l := newList()
+		items := make([]H, 6)
+
+		for i := range items {
+			items[i] = l.PushBack(i)
+		}
+
+		for _, i := range []int{3, 0, 5} {
+			l.Unlink(items[i])
+		}
+
+		expect(t, l, 1, 2, 4)
+
+		for _, i := range []int{5, 0, 3} {
+			l.Insert(items[i])
+		}
+
+		expect(t, l, 3, 0, 5, 1, 2, 4)
+	})
+
+	t.Run("IterStop", func(t *testing.T) {
+		l := newList()
+
+		for i := range 5 {
+			l.PushBack(i)
+		}
+
+		for v := range l.All() {
+			if v == 2 {
+				break
+			}
+
+			l.Remove(v)
+		}
+
+		expect(t, l, 2, 3, 4)
+	})
+}
@@ -0,0 +1,343 @@
+// XOR linked list stored in a slice: each cell keeps a single link, the XOR
+// of its neighbours' indices, instead of separate next and prev links. The
+// list can be walked in either direction from an end, but finding the
+// neighbours of an arbitrary cell means walking to it, so operations given a
+// cell take O(n) where alist takes O(1).
+//
+// Cell 0 is never used, so that None is 0 and XOR-ing with a missing
+// neighbour leaves the link unchanged.
+
+package xlist
+
+import (
+	"errors"
+	"iter"
+)
+
+// Index returned when there is no cell.
+const None = 0
+
+const (
+	free = iota
+	unlinked
+	linked
+)
+
+type cell[T comparable] struct {
+	Head T
+	// prev ^ next while linked, the next free cell while free
+	link  int
+	state uint8
+}
+
+type XORList[T comparable] struct {
+	cells  []cell[T]
+	first  int
+	last   int
+	free   int
+	length int
+}
+
+func New[T comparable]() *XORList[T] {
+	return NewWithCapacity[T](0)
+}
+
+// Creates a list with room for n cells before the arena has to grow.
+func NewWithCapacity[T comparable](n int) *XORList[T] {
+	cells := make([]cell[T], 1, n+1)
+
+	return &XORList[T]{cells: cells}
+}
+
+func (l *XORList[T]) First() int {
+	return l.first
+}
+
+func (l *XORList[T]) Last() int {
+	return l.last
+}
+
+// Returns the neighbour of cell i that isn't from. Walking forward passes the
+// previous cell, walking backward the next one.
+func (l *XORList[T]) Next(from, i int) int {
+	return l.cells[i].link ^ from
+}
+
+// Returns the value of cell i.
+func (l *XORList[T]) Get(i int) T {
+	return l.cells[i].Head
+}
+
+func (l *XORList[T]) Length() int {
+	return l.length
+}
+
+func (l *XORList[T]) Empty() bool {
+	return l.length == 0
+}
+
+func (l *XORList[T]) is(i int, state uint8) bool {
+	return i > 0 && i < len(l.cells) && l.cells[i].state == state
+}
+
+// Returns an unlinked cell holding value, reusing a freed cell if possible.
+func (l *XORList[T]) alloc(value T) int {
+	c := cell[T]{Head: value, state: unlinked}
+
+	if l.free == None {
+		l.cells = append(l.cells, c)
+		return len(l.cells) - 1
+	}
+
+	i := l.free
+	l.free = l.cells[i].link
+	l.cells[i] = c
+
+	return i
+}
+
+func (l *XORList[T]) release(i int) {
+	l.cells[i] = cell[T]{link: l.free, state: free}
+	l.free = i
+}
+
+// Returns the cell before the linked cell i by walking from the front.
+func (l *XORList[T]) prev(i int) int {
+	if i == l.last {
+		return l.cells[i].link
+	}
+
+	prev := None
+
+	for cur := l.first; cur != i; {
+		prev, cur = cur, l.cells[cur].link^prev
+	}
+
+	return prev
+}
+
+// Links cell i between the adjacent cells prev and next, either of which may
+// be None at the ends.
+func (l *XORList[T]) link(i, prev, next int) {
+	l.cells[i].link = prev ^ next
+	l.cells[i].state = linked
+
+	if prev != None {
+		l.cells[prev].link ^= next ^ i
+	} else {
+		l.first = i
+	}
+
+	if next != None {
+		l.cells[next].link ^= prev ^ i
+	} else {
+		l.last = i
+	}
+
+	l.length++
+}
+
+// Unlinks cell i, whose neighbour before it is prev.
+func (l *XORList[T]) unlink(i, prev int) {
+	next := l.cells[i].link ^ prev
+
+	if prev != None {
+		l.cells[prev].link ^= i ^ next
+	} else {
+		l.first = next
+	}
+
+	if next != None {
+		l.cells[next].link ^= i ^ prev
+	} else {
+		l.last = prev
+	}
+
+	l.cells[i].link = None
+	l.cells[i].state = unlinked
+	l.length--
+}
+
+// Same as PushFront.
+func (l *XORList[T]) Add(value T) int {
+	return l.PushFront(value)
+}
+
+func (l *XORList[T]) PushFront(value T) int {
+	i := l.alloc(value)
+	l.link(i, None, l.first)
+
+	return i
+}
+
+func (l *XORList[T]) PushBack(value T) int {
+	i := l.alloc(value)
+	l.link(i, l.last, None)
+
+	return i
+}
+
+func (l *XORList[T]) PopFront() (T, error) {
+	if l.first == None {
+		var zero T
+		return zero, errors.New("list is empty")
+	}
+
+	i := l.first
+	value := l.cells[i].Head
+	l.unlink(i, None)
+	l.release(i)
+
+	return value, nil
+}
+
+func (l *XORList[T]) PopBack() (T, error) {
+	if l.last == None {
+		var zero T
+		return zero, errors.New("list is empty")
+	}
+
+	i := l.last
+	value := l.cells[i].Head
+	l.unlink(i, l.cells[i].link)
+	l.release(i)
+
+	return value, nil
+}
+
+// Inserts value before mark, which must be linked.
+func (l *XORList[T]) InsertBefore(value T, mark int) int {
+	if !l.is(mark, linked) {
+		return None
+	}
+
+	prev := l.prev(mark)
+	i := l.alloc(value)
+	l.link(i, prev, mark)
+
+	return i
+}
+
+// Inserts value after mark, which must be linked.
+func (l *XORList[T]) InsertAfter(value T, mark int) int {
+	if !l.is(mark, linked) {
+		return None
+	}
+
+	next := l.cells[mark].link ^ l.prev(mark)
+	i := l.alloc(value)
+	l.link(i, mark, next)
+
+	return i
+}
+
+func (l *XORList[T]) MoveToFront(i int) {
+	if !l.is(i, linked) || l.first == i {
+		return
+	}
+
+	l.unlink(i, l.prev(i))
+	l.link(i, None, l.first)
+}
+
+func (l *XORList[T]) MoveToBack(i int) {
+	if !l.is(i, linked) || l.last == i {
+		return
+	}
+
+	l.unlink(i, l.prev(i))
+	l.link(i, l.last, None)
+}
+
+func (l *XORList[T]) Find(value T) int {
+	prev := None
+
+	for i := l.first; i != None; {
+		if l.cells[i].Head == value {
+			return i
+		}
+
+		prev, i = i, l.cells[i].link^prev
+	}
+
+	return None
+}
+
+// Removes the first cell holding value and frees it.
+func (l *XORList[T]) Remove(value T) {
+	prev := None
+
+	for i := l.first; i != None; {
+		if l.cells[i].Head == value {
+			l.unlink(i, prev)
+			l.release(i)
+
+			return
+		}
+
+		prev, i = i, l.cells[i].link^prev
+	}
+}
+
+// Removes cell i from the list but keeps it allocated, so it can be linked
+// again with Insert. Cells that aren't linked are ignored.
+func (l *XORList[T]) Unlink(i int) {
+	if !l.is(i, linked) {
+		return
+	}
+
+	l.unlink(i, l.prev(i))
+}
+
+// Links an unlinked cell at the front of the list.
+func (l *XORList[T]) Insert(i int) {
+	if !l.is(i, unlinked) {
+		return
+	}
+
+	l.link(i, None, l.first)
+}
+
+// Frees an unlinked cell for reuse.
+func (l *XORList[T]) Free(i int) {
+	if !l.is(i, unlinked) {
+		return
+	}
+
+	l.release(i)
+}
+
+// Iterates over the values starting at the end from, which works in both
+// directions since the links are symmetric.
+func (l *XORList[T]) walk(from int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		prev := None
+
+		for i := from; i != None; {
+			next := l.cells[i].link ^ prev
+
+			if !yield(l.cells[i].Head) {
+				return
+			}
+
+			// If the cell was removed, prev is now next's neighbour
+			if l.cells[i].state == linked && l.cells[i].link == prev^next {
+				prev = i
+			}
+
+			i = next
+		}
+	}
+}
+
+// Iterates over the values from first to last. The current value may be
+// removed during iteration.
+func (l *XORList[T]) All() iter.Seq[T] {
+	return l.walk(l.first)
+}
+
+// Iterates over the values from last to first. The current value may be
+// removed during iteration.
+func (l *XORList[T]) Backward() iter.Seq[T] {
+	return l.walk(l.last)
+}
@@ -0,0 +1,96 @@
+package xlist
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/phanty133/id1021/4-linkedlist/pkg/listtest"
+)
+
+func TestSuite(t *testing.T) {
+	listtest.Run(t, New[int], None)
+}
+
+func TestReuse(t *testing.T) {
+	l := New[int]()
+	a := l.PushBack(1)
+	b := l.PushBack(2)
+	l.Remove(1)
+
+	// The freed cell is reused before the arena grows
+	if c := l.PushBack(3); c != a || len(l.cells) != 3 {
+		t.Errorf("got cell %d with %d cells, want %d with 3", c, len(l.cells), a)
+	}
+
+	l.Unlink(b)
+	l.Free(b)
+
+	if l.PushFront(4) != b {
+		t.Error("freed cell wasn't reused")
+	}
+
+	if l.InsertAfter(0, 100) != None || l.InsertBefore(0, None) != None {
+		t.Error("insert accepted an invalid mark")
+	}
+
+	listtest.Expect(t, listtest.List[int](l), None, 4, 3)
+}
+
+func TestNext(t *testing.T) {
+	l := New[int]()
+
+	for i := range 4 {
+		l.PushBack(i)
+	}
+
+	got := []int{}
+
+	for prev, i := None, l.First(); i != None; prev, i = i, l.Next(prev, i) {
+		got = append(got, l.Get(i))
+	}
+
+	if !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("walked %v", got)
+	}
+}
+
+// Applies random operations to the list and to a slice and compares them.
+func TestAgainstSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	l := New[int]()
+	want := []int{}
+
+	for i := 0; i < 3000; i++ {
+		v := rng.Intn(40)
+
+		switch rng.Intn(5) {
+		case 0:
+			l.PushFront(v)
+			want = slices.Insert(want, 0, v)
+		case 1:
+			l.PushBack(v)
+			want = append(want, v)
+		case 2:
+			l.Remove(v)
+
+			if idx := slices.Index(want, v); idx >= 0 {
+				want = slices.Delete(want, idx, idx+1)
+			}
+		case 3:
+			if mark := l.Find(v); mark != None {
+				l.InsertAfter(i, mark)
+				idx := slices.Index(want, v)
+				want = slices.Insert(want, idx+1, i)
+			}
+		case 4:
+			if item := l.Find(v); item != None {
+				l.MoveToBack(item)
+				idx := slices.Index(want, v)
+				want = append(slices.Delete(want, idx, idx+1), v)
+			}
+		}
+
+		listtest.Expect(t, listtest.List[int](l), None, want...)
+	}
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"encoding/csv"
 	"fmt"
+	"github.com/phanty133/id1021/6-trees/pkg/btree"
+	"github.com/phanty133/id1021/6-trees/pkg/skiplist"
 	"github.com/phanty133/id1021/6-trees/pkg/tree"
 	"math/rand"
 	"os"
+	"slices"
 	"strconv"
 	"time"
 )
@@ -13,11 +16,18 @@ import (
 /*
 Benchmarks:
 - Lookup and add exec time for growing node count (dont construct with ordered keys plox)
+- Shape of each of those trees: height, average depth, leaves, depth and balance distributions
+- Skip list lookup with the same keys for comparison
+- Add and lookup exec time for unbalanced, AVL and red-black trees built from ordered keys
+- Full in-order traversal with the channel iterator, iter.Seq2 and a cursor
+- Add and lookup exec time for BinaryTree and BTree at large sizes
 */
 
 type TimeEntry struct {
-	Lookup int
-	Add    int
+	Lookup     int
+	Add        int
+	SkipLookup int
+	Stats      tree.Stats
 }
 
 func BenchmarkTree() {
@@ -36,12 +46,16 @@ func BenchmarkTree() {
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	cols := make([]string, len(sizes)*2+1)
+	cols := make([]string, len(sizes)*6+1)
 
 	cols[0] = "repeat"
 	for i, size := range sizes {
 		cols[i+1] = "lookup-" + strconv.Itoa(size)
 		cols[i+1+len(sizes)] = "add-" + strconv.Itoa(size)
+		cols[i+1+2*len(sizes)] = "skiplist-lookup-" + strconv.Itoa(size)
+		cols[i+1+3*len(sizes)] = "height-" + strconv.Itoa(size)
+		cols[i+1+4*len(sizes)] = "avg-depth-" + strconv.Itoa(size)
+		cols[i+1+5*len(sizes)] = "leaves-" + strconv.Itoa(size)
 	}
 
 	writer.Write(cols)
@@ -52,9 +66,12 @@ func BenchmarkTree() {
 
 		for i := 0; i < repeats; i++ {
 			tree := tree.NewBinaryTree[int, int]()
+			list := skiplist.New[int, int]()
 
 			for i := 0; i < size; i++ {
-				tree.Add(rand.Int(), rand.Int())
+				key, val := rand.Int(), rand.Int()
+				tree.Add(key, val)
+				list.Insert(key, val)
 			}
 
 			entry := TimeEntry{}
@@ -74,8 +91,18 @@ func BenchmarkTree() {
 
 			lookupTime := float32(time.Since(lookupStart).Nanoseconds()) / 1000
 
+			skipStart := time.Now()
+
+			for i := 0; i < adds; i++ {
+				list.Lookup(rand.Int())
+			}
+
+			skipTime := float32(time.Since(skipStart).Nanoseconds()) / 1000
+
 			entry.Lookup = int(lookupTime)
+			entry.SkipLookup = int(skipTime)
 			entry.Add = int(0)
+			entry.Stats = tree.Stats()
 			times[sizeIdx][i] = entry
 
 			if i%100 == 0 {
@@ -87,20 +114,302 @@ func BenchmarkTree() {
 	}
 
 	for i := 0; i < repeats; i++ {
-		row := make([]string, len(sizes)*2+1)
+		row := make([]string, len(sizes)*6+1)
 		row[0] = strconv.Itoa(i)
 
 		for sizeIdx, _ := range sizes {
+			stats := times[sizeIdx][i].Stats
 			row[sizeIdx+1] = strconv.Itoa(times[sizeIdx][i].Lookup)
 			row[sizeIdx+1+len(sizes)] = strconv.Itoa(times[sizeIdx][i].Add)
+			row[sizeIdx+1+2*len(sizes)] = strconv.Itoa(times[sizeIdx][i].SkipLookup)
+			row[sizeIdx+1+3*len(sizes)] = strconv.Itoa(stats.Height)
+			row[sizeIdx+1+4*len(sizes)] = strconv.FormatFloat(stats.AvgDepth, 'f', 3, 64)
+			row[sizeIdx+1+5*len(sizes)] = strconv.Itoa(stats.Leaves)
 		}
 
 		writer.Write(row)
 	}
+
+	shapes := make([]tree.Stats, len(sizes))
+
+	for sizeIdx := range sizes {
+		shapes[sizeIdx] = times[sizeIdx][repeats-1].Stats
+	}
+
+	WriteShapes(sizes, shapes)
+}
+
+// Writes the depth histogram and balance factor distribution of one tree per
+// size, one row per depth or balance factor.
+func WriteShapes(sizes []int, shapes []tree.Stats) {
+	file, err := os.Create("bench-shape.csv")
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	writer.Write([]string{"size", "kind", "value", "nodes"})
+
+	for sizeIdx, size := range sizes {
+		stats := shapes[sizeIdx]
+
+		for depth, count := range stats.DepthHistogram {
+			writer.Write([]string{strconv.Itoa(size), "depth", strconv.Itoa(depth), strconv.Itoa(count)})
+		}
+
+		factors := make([]int, 0, len(stats.BalanceFactors))
+
+		for factor := range stats.BalanceFactors {
+			factors = append(factors, factor)
+		}
+
+		slices.Sort(factors)
+
+		for _, factor := range factors {
+			count := stats.BalanceFactors[factor]
+			writer.Write([]string{strconv.Itoa(size), "balance", strconv.Itoa(factor), strconv.Itoa(count)})
+		}
+	}
+}
+
+func BenchmarkOrdered() {
+	sizes := []int{100, 1000, 5000, 10000}
+	lookups := 1000
+	repeats := 25
+
+	trees := []struct {
+		Name string
+		New  func() tree.Tree[int, int]
+	}{
+		{"binary", func() tree.Tree[int, int] { return tree.NewBinaryTree[int, int]() }},
+		{"avl", func() tree.Tree[int, int] { return tree.NewAVLTree[int, int]() }},
+		{"redblack", func() tree.Tree[int, int] { return tree.NewRedBlackTree[int, int]() }},
+	}
+
+	file, err := os.Create("bench-ordered.csv")
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	cols := []string{"repeat"}
+
+	for _, t := range trees {
+		for _, size := range sizes {
+			cols = append(cols, t.Name+"-add-"+strconv.Itoa(size), t.Name+"-lookup-"+strconv.Itoa(size))
+		}
+	}
+
+	writer.Write(cols)
+	rows := make([][]string, repeats)
+
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+	}
+
+	for _, t := range trees {
+		for _, size := range sizes {
+			for i := 0; i < repeats; i++ {
+				tree := t.New()
+				addStart := time.Now()
+
+				for key := 0; key < size; key++ {
+					tree.Add(key, key)
+				}
+
+				addTime := float32(time.Since(addStart).Nanoseconds()) / 1000
+				lookupStart := time.Now()
+
+				for j := 0; j < lookups; j++ {
+					tree.Lookup(rand.Intn(size))
+				}
+
+				lookupTime := float32(time.Since(lookupStart).Nanoseconds()) / 1000
+				rows[i] = append(rows[i], strconv.Itoa(int(addTime)), strconv.Itoa(int(lookupTime)))
+			}
+
+			fmt.Printf("Finished %s size %d\n", t.Name, size)
+		}
+	}
+
+	writer.WriteAll(rows)
+}
+
+func BenchmarkIterators() {
+	sizes := []int{1000, 10000, 100000, 1000000}
+	repeats := 25
+
+	iterators := []struct {
+		Name string
+		Walk func(t *tree.BinaryTree[int, int]) int
+	}{
+		{"channel", func(t *tree.BinaryTree[int, int]) int {
+			sum := 0
+
+			for node := range t.DepthFirstIterator() {
+				sum += node.Val
+			}
+
+			return sum
+		}},
+		{"seq", func(t *tree.BinaryTree[int, int]) int {
+			sum := 0
+
+			for _, val := range t.InOrder() {
+				sum += val
+			}
+
+			return sum
+		}},
+		{"cursor", func(t *tree.BinaryTree[int, int]) int {
+			sum := 0
+
+			for c := t.Cursor(); c.Valid(); c.Next() {
+				sum += c.Val()
+			}
+
+			return sum
+		}},
+	}
+
+	file, err := os.Create("bench-iter.csv")
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	cols := []string{"repeat"}
+
+	for _, it := range iterators {
+		for _, size := range sizes {
+			cols = append(cols, it.Name+"-"+strconv.Itoa(size))
+		}
+	}
+
+	writer.Write(cols)
+	rows := make([][]string, repeats)
+
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+	}
+
+	trees := make([]*tree.BinaryTree[int, int], len(sizes))
+
+	for sizeIdx, size := range sizes {
+		trees[sizeIdx] = tree.NewBinaryTree[int, int]()
+
+		for i := 0; i < size; i++ {
+			trees[sizeIdx].Add(rand.Int(), rand.Intn(100))
+		}
+	}
+
+	for _, it := range iterators {
+		for sizeIdx, size := range sizes {
+			for i := 0; i < repeats; i++ {
+				start := time.Now()
+				it.Walk(trees[sizeIdx])
+				walkTime := float32(time.Since(start).Nanoseconds()) / 1000
+
+				rows[i] = append(rows[i], strconv.Itoa(int(walkTime)))
+			}
+
+			fmt.Printf("Finished %s size %d\n", it.Name, size)
+		}
+	}
+
+	writer.WriteAll(rows)
+}
+
+func BenchmarkBTree() {
+	sizes := []int{100000, 250000, 500000, 1000000}
+	lookups := 100000
+	repeats := 10
+
+	trees := []struct {
+		Name string
+		New  func() tree.Tree[int, int]
+	}{
+		{"binary", func() tree.Tree[int, int] { return tree.NewBinaryTree[int, int]() }},
+		{"btree", func() tree.Tree[int, int] { return btree.New[int, int]() }},
+	}
+
+	file, err := os.Create("bench-btree.csv")
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	cols := []string{"repeat"}
+
+	for _, t := range trees {
+		for _, size := range sizes {
+			cols = append(cols, t.Name+"-add-"+strconv.Itoa(size), t.Name+"-lookup-"+strconv.Itoa(size))
+		}
+	}
+
+	writer.Write(cols)
+	rows := make([][]string, repeats)
+
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+	}
+
+	for _, t := range trees {
+		for _, size := range sizes {
+			for i := 0; i < repeats; i++ {
+				// Same keys for both trees in each repeat
+				rng := rand.New(rand.NewSource(int64(i)))
+				tree := t.New()
+				addStart := time.Now()
+
+				for j := 0; j < size; j++ {
+					key := rng.Int()
+					tree.Add(key, key)
+				}
+
+				addTime := float32(time.Since(addStart).Nanoseconds()) / 1000
+				lookupStart := time.Now()
+
+				for j := 0; j < lookups; j++ {
+					tree.Lookup(rng.Int())
+				}
+
+				lookupTime := float32(time.Since(lookupStart).Nanoseconds()) / 1000
+				rows[i] = append(rows[i], strconv.Itoa(int(addTime)), strconv.Itoa(int(lookupTime)))
+			}
+
+			fmt.Printf("Finished %s size %d\n", t.Name, size)
+		}
+	}
+
+	writer.WriteAll(rows)
 }
 
 func main() {
 	BenchmarkTree()
+	BenchmarkOrdered()
+	BenchmarkIterators()
+	BenchmarkBTree()
 
 	// tree := tree.NewBinaryTree[int, int]()
 
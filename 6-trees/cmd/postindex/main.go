@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/phanty133/id1021/6-trees/pkg/bptree"
+)
+
+/*
+Stores postnummer.csv in an on-disk B+tree keyed by postcode, so it doesn't
+have to be parsed again on every run.
+
+Usage:
+  postindex [-db file] build postnummer.csv
+  postindex [-db file] get "111 15"
+  postindex [-db file] range "111 15" "112 00"
+*/
+
+// Inserts every row of the CSV file and commits them together. On error
+// everything since the last commit is rolled back, so a failed build leaves
+// no partial data behind.
+func build(tree *bptree.BPTree, path string) (int, error) {
+	n, err := insertRows(tree, path)
+
+	if err != nil {
+		return n, errors.Join(err, tree.Rollback())
+	}
+
+	return n, tree.Commit()
+}
+
+func insertRows(tree *bptree.BPTree, path string) (int, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	n := 0
+
+	for {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return n, err
+		}
+
+		if len(record) < 3 {
+			line, _ := reader.FieldPos(0)
+			return n, fmt.Errorf("line %d: expected 3 columns, got %d", line, len(record))
+		}
+
+		code := strings.TrimSpace(record[0])
+		val := strings.TrimSpace(record[1]) + "," + strings.TrimSpace(record[2])
+
+		if err := tree.Insert([]byte(code), []byte(val)); err != nil {
+			return n, err
+		}
+
+		n++
+	}
+
+	return n, nil
+}
+
+func run(tree *bptree.BPTree, args []string) error {
+	switch {
+	case len(args) == 2 && args[0] == "build":
+		n, err := build(tree, args[1])
+
+		if err == nil {
+			fmt.Printf("Stored %d postcodes\n", n)
+		}
+
+		return err
+	case len(args) == 2 && args[0] == "get":
+		val, ok, err := tree.Lookup([]byte(args[1]))
+
+		if err == nil && !ok {
+			err = fmt.Errorf("postcode %s not found", args[1])
+		}
+
+		if err == nil {
+			fmt.Printf("%s,%s\n", args[1], val)
+		}
+
+		return err
+	case len(args) == 3 && args[0] == "range":
+		return tree.Scan([]byte(args[1]), []byte(args[2]), func(key, val []byte) bool {
+			fmt.Printf("%s,%s\n", key, val)
+			return true
+		})
+	}
+
+	return errors.New("usage: postindex [-db file] build <csv> | get <code> | range <lo> <hi>")
+}
+
+func main() {
+	dbPath := flag.String("db", "postnummer.db", "B+tree file")
+	flag.Parse()
+
+	tree, err := bptree.Open(*dbPath)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	err = run(tree, flag.Args())
+	err = errors.Join(err, tree.Close())
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
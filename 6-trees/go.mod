@@ -1,3 +1,3 @@
 module github.com/phanty133/id1021/6-trees
 
-go 1.21
+go 1.23
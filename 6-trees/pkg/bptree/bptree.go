@@ -0,0 +1,473 @@
+// B+tree stored in a page file. Values live only in the leaves, which are
+// chained left to right for range scans, and each node is one page. Keys are
+// compared as bytes.
+//
+// Changes are staged in memory until Commit, which applies them atomically
+// through the page file's write-ahead log.
+
+package bptree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/phanty133/id1021/6-trees/pkg/pagefile"
+)
+
+const (
+	MaxKeySize   = 256
+	MaxValueSize = 512
+
+	// Pages kept in the buffer pool
+	DefaultCacheSize = 256
+)
+
+var (
+	ErrKeySize   = errors.New("key is empty or too large")
+	ErrValueSize = errors.New("value is too large")
+	ErrCorrupt   = errors.New("corrupt node page")
+	// Wrapped by Insert errors that rolled back every change since the last
+	// commit, not just the failed insert
+	ErrRolledBack = errors.New("uncommitted changes rolled back")
+)
+
+const (
+	leafPage     = 1
+	internalPage = 2
+
+	// Type, key count and the next leaf or first child
+	headerSize = 1 + 2 + 4
+)
+
+// Decoded node. Internal nodes have one more child than keys, and every key in
+// children[i] is less than keys[i], which is less than or equal to every key
+// in children[i+1].
+type node struct {
+	leaf     bool
+	keys     [][]byte
+	vals     [][]byte
+	children []pagefile.PageID
+	// Next leaf to the right, 0 for the last one
+	next pagefile.PageID
+}
+
+// Size of the node once encoded.
+func (n *node) size() int {
+	size := headerSize
+
+	for i, key := range n.keys {
+		if n.leaf {
+			size += 4 + len(key) + len(n.vals[i])
+		} else {
+			size += 2 + len(key) + 4
+		}
+	}
+
+	return size
+}
+
+// Page layout: type, key count, then for leaves the next leaf and each key and
+// value with their lengths, and for internal nodes the first child and each
+// key with its length and the child after it.
+func (n *node) encode(buf []byte) {
+	clear(buf)
+	binary.LittleEndian.PutUint16(buf[1:], uint16(len(n.keys)))
+	off := headerSize
+
+	if n.leaf {
+		buf[0] = leafPage
+		binary.LittleEndian.PutUint32(buf[3:], uint32(n.next))
+
+		for i, key := range n.keys {
+			binary.LittleEndian.PutUint16(buf[off:], uint16(len(key)))
+			binary.LittleEndian.PutUint16(buf[off+2:], uint16(len(n.vals[i])))
+			off += 4
+			off += copy(buf[off:], key)
+			off += copy(buf[off:], n.vals[i])
+		}
+
+		return
+	}
+
+	buf[0] = internalPage
+	binary.LittleEndian.PutUint32(buf[3:], uint32(n.children[0]))
+
+	for i, key := range n.keys {
+		binary.LittleEndian.PutUint16(buf[off:], uint16(len(key)))
+		off += 2
+		off += copy(buf[off:], key)
+		binary.LittleEndian.PutUint32(buf[off:], uint32(n.children[i+1]))
+		off += 4
+	}
+}
+
+// Decodes a page into a node. Keys and values are copied out of the page.
+func decode(buf []byte) (*node, error) {
+	if buf[0] != leafPage && buf[0] != internalPage {
+		return nil, ErrCorrupt
+	}
+
+	count := int(binary.LittleEndian.Uint16(buf[1:]))
+	n := &node{
+		leaf: buf[0] == leafPage,
+		keys: make([][]byte, 0, count+1),
+	}
+
+	first := pagefile.PageID(binary.LittleEndian.Uint32(buf[3:]))
+	off := headerSize
+
+	// Reads the next length-prefixed field
+	read := func(length int) []byte {
+		if off+length > len(buf) {
+			return nil
+		}
+
+		field := slices.Clone(buf[off : off+length])
+		off += length
+
+		return field
+	}
+
+	if n.leaf {
+		n.next = first
+		n.vals = make([][]byte, 0, count+1)
+	} else {
+		n.children = append(make([]pagefile.PageID, 0, count+2), first)
+	}
+
+	for range count {
+		if off+4 > len(buf) {
+			return nil, ErrCorrupt
+		}
+
+		if n.leaf {
+			keyLen := int(binary.LittleEndian.Uint16(buf[off:]))
+			valLen := int(binary.LittleEndian.Uint16(buf[off+2:]))
+			off += 4
+			key, val := read(keyLen), read(valLen)
+
+			if key == nil || val == nil {
+				return nil, ErrCorrupt
+			}
+
+			n.keys = append(n.keys, key)
+			n.vals = append(n.vals, val)
+		} else {
+			keyLen := int(binary.LittleEndian.Uint16(buf[off:]))
+			off += 2
+			key := read(keyLen)
+
+			if key == nil || off+4 > len(buf) {
+				return nil, ErrCorrupt
+			}
+
+			n.keys = append(n.keys, key)
+			n.children = append(n.children, pagefile.PageID(binary.LittleEndian.Uint32(buf[off:])))
+			off += 4
+		}
+	}
+
+	return n, nil
+}
+
+// Index of the first key in n greater than or equal to key, and whether it is
+// equal.
+func (n *node) search(key []byte) (int, bool) {
+	return slices.BinarySearchFunc(n.keys, key, bytes.Compare)
+}
+
+// Index of the child whose subtree may hold key.
+func (n *node) child(key []byte) int {
+	i, found := n.search(key)
+
+	if found {
+		i++
+	}
+
+	return i
+}
+
+type BPTree struct {
+	pager *pagefile.Pager
+}
+
+// Opens or creates the tree stored in the file at path.
+func Open(path string) (*BPTree, error) {
+	return OpenWithCacheSize(path, DefaultCacheSize)
+}
+
+// Same as Open but keeps up to cacheSize pages in memory.
+func OpenWithCacheSize(path string, cacheSize int) (*BPTree, error) {
+	pager, err := pagefile.Open(path, cacheSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	t := &BPTree{pager}
+
+	if pager.Root() == 0 {
+		// New file, start with an empty leaf as the root
+		err = t.writeNew(&node{leaf: true}, func(id pagefile.PageID) {
+			pager.SetRoot(id)
+		})
+
+		if err == nil {
+			err = pager.Commit()
+		}
+
+		if err != nil {
+			pager.Close()
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// Commits pending changes and closes the file.
+func (t *BPTree) Close() error {
+	if err := t.pager.Commit(); err != nil {
+		t.pager.Close()
+		return err
+	}
+
+	return t.pager.Close()
+}
+
+// Makes all inserts since the last commit durable. Until then they are visible
+// to lookups but lost if the process exits without committing.
+func (t *BPTree) Commit() error {
+	return t.pager.Commit()
+}
+
+// Discards all inserts since the last commit.
+func (t *BPTree) Rollback() error {
+	return t.pager.Rollback()
+}
+
+func (t *BPTree) read(id pagefile.PageID) (*node, error) {
+	page, err := t.pager.Get(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer t.pager.Release(page)
+
+	return decode(page.Data)
+}
+
+func (t *BPTree) write(id pagefile.PageID, n *node) error {
+	page, err := t.pager.Get(id)
+
+	if err != nil {
+		return err
+	}
+
+	n.encode(page.Data)
+	t.pager.MarkDirty(page)
+	t.pager.Release(page)
+
+	return nil
+}
+
+// Writes n to a new page and passes its ID to link before releasing it.
+func (t *BPTree) writeNew(n *node, link func(pagefile.PageID)) error {
+	page, err := t.pager.Alloc()
+
+	if err != nil {
+		return err
+	}
+
+	n.encode(page.Data)
+	link(page.ID)
+	t.pager.Release(page)
+
+	return nil
+}
+
+func (t *BPTree) Lookup(key []byte) ([]byte, bool, error) {
+	n, err := t.findLeaf(key)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if i, found := n.search(key); found {
+		return n.vals[i], true, nil
+	}
+
+	return nil, false, nil
+}
+
+// Returns the leaf whose range covers key.
+func (t *BPTree) findLeaf(key []byte) (*node, error) {
+	n, err := t.read(t.pager.Root())
+
+	for err == nil && !n.leaf {
+		n, err = t.read(n.children[n.child(key)])
+	}
+
+	return n, err
+}
+
+// Sets the value of key, adding it if it isn't in the tree.
+//
+// An insert that fails part way, e.g. on a read error, can't be undone on its
+// own, so every change since the last commit is rolled back and the error
+// wraps ErrRolledBack. Invalid keys and values are rejected before anything
+// changes.
+func (t *BPTree) Insert(key, val []byte) error {
+	if len(key) == 0 || len(key) > MaxKeySize {
+		return ErrKeySize
+	}
+
+	if len(val) > MaxValueSize {
+		return ErrValueSize
+	}
+
+	root := t.pager.Root()
+	sep, right, err := t.insert(root, key, val)
+
+	if err == nil && right != 0 {
+		// The root split, so the tree grows by one level
+		newRoot := &node{keys: [][]byte{sep}, children: []pagefile.PageID{root, right}}
+		err = t.writeNew(newRoot, t.pager.SetRoot)
+	}
+
+	if err != nil {
+		// Don't leave a half finished insert to be committed
+		return errors.Join(fmt.Errorf("%w: %w", ErrRolledBack, err), t.pager.Rollback())
+	}
+
+	return nil
+}
+
+// Inserts into the subtree at id. If the node had to split, returns the first
+// key of the new right node and its page.
+func (t *BPTree) insert(id pagefile.PageID, key, val []byte) ([]byte, pagefile.PageID, error) {
+	n, err := t.read(id)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if n.leaf {
+		i, found := n.search(key)
+
+		if found {
+			n.vals[i] = slices.Clone(val)
+		} else {
+			n.keys = slices.Insert(n.keys, i, slices.Clone(key))
+			n.vals = slices.Insert(n.vals, i, slices.Clone(val))
+		}
+	} else {
+		i := n.child(key)
+		sep, right, err := t.insert(n.children[i], key, val)
+
+		if err != nil || right == 0 {
+			return nil, 0, err
+		}
+
+		n.keys = slices.Insert(n.keys, i, sep)
+		n.children = slices.Insert(n.children, i+1, right)
+	}
+
+	if n.size() <= pagefile.PageSize {
+		return nil, 0, t.write(id, n)
+	}
+
+	return t.split(id, n)
+}
+
+// Splits an overflowing node in two halves by size, writing the left half
+// back to id.
+func (t *BPTree) split(id pagefile.PageID, n *node) ([]byte, pagefile.PageID, error) {
+	half := n.size() / 2
+	size := headerSize
+	mid := 0
+
+	for size < half {
+		if n.leaf {
+			size += 4 + len(n.keys[mid]) + len(n.vals[mid])
+		} else {
+			size += 2 + len(n.keys[mid]) + 4
+		}
+
+		mid++
+	}
+
+	// Both halves need at least one key, and an internal node also gives up
+	// its middle key to the parent
+	mid = min(max(mid, 1), len(n.keys)-1)
+	var sep []byte
+	right := &node{leaf: n.leaf}
+
+	if n.leaf {
+		sep = n.keys[mid]
+		right.keys = slices.Clone(n.keys[mid:])
+		right.vals = slices.Clone(n.vals[mid:])
+		right.next = n.next
+		n.keys = n.keys[:mid]
+		n.vals = n.vals[:mid]
+	} else {
+		sep = n.keys[mid]
+		right.keys = slices.Clone(n.keys[mid+1:])
+		right.children = slices.Clone(n.children[mid+1:])
+		n.keys = n.keys[:mid]
+		n.children = n.children[:mid+1]
+	}
+
+	var rightID pagefile.PageID
+	err := t.writeNew(right, func(id pagefile.PageID) {
+		rightID = id
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if n.leaf {
+		n.next = rightID
+	}
+
+	return sep, rightID, t.write(id, n)
+}
+
+// Calls fn with each key in [lo, hi] and its value in order, until fn returns
+// false. A nil hi means no upper bound.
+func (t *BPTree) Scan(lo, hi []byte, fn func(key, val []byte) bool) error {
+	n, err := t.findLeaf(lo)
+
+	if err != nil {
+		return err
+	}
+
+	i, _ := n.search(lo)
+
+	for {
+		for ; i < len(n.keys); i++ {
+			if hi != nil && bytes.Compare(n.keys[i], hi) > 0 {
+				return nil
+			}
+
+			if !fn(n.keys[i], n.vals[i]) {
+				return nil
+			}
+		}
+
+		if n.next == 0 {
+			return nil
+		}
+
+		if n, err = t.read(n.next); err != nil {
+			return err
+		}
+
+		i = 0
+	}
+}
@@ -0,0 +1,205 @@
+package bptree
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"math/rand"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func open(t *testing.T, path string) *BPTree {
+	t.Helper()
+
+	// A small cache so that pages are evicted and read back
+	tree, err := OpenWithCacheSize(path, 8)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tree
+}
+
+// Checks lookups and a full scan against want.
+func check(t *testing.T, tree *BPTree, want map[string]string) {
+	t.Helper()
+
+	for key, val := range want {
+		got, ok, err := tree.Lookup([]byte(key))
+
+		if err != nil || !ok || string(got) != val {
+			t.Fatalf("Lookup(%q): got %q, %t, %v, want %q", key, got, ok, err, val)
+		}
+	}
+
+	if _, ok, _ := tree.Lookup([]byte("missing")); ok {
+		t.Fatal("found a key that was never inserted")
+	}
+
+	keys := []string{}
+	err := tree.Scan(nil, nil, func(key, val []byte) bool {
+		if want[string(key)] != string(val) {
+			t.Fatalf("Scan: key %q has value %q", key, val)
+		}
+
+		keys = append(keys, string(key))
+		return true
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(keys, slices.Sorted(maps.Keys(want))) {
+		t.Fatalf("Scan returned %d keys out of order or missing, want %d", len(keys), len(want))
+	}
+}
+
+func TestInsertReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree")
+	tree := open(t, path)
+	rng := rand.New(rand.NewSource(1))
+	want := map[string]string{}
+
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("key-%06d", rng.Intn(3000))
+		// Values of varying size so that nodes split at different counts
+		val := fmt.Sprintf("%d-%s", i, bytes.Repeat([]byte{'v'}, rng.Intn(200)))
+
+		if err := tree.Insert([]byte(key), []byte(val)); err != nil {
+			t.Fatal(err)
+		}
+
+		want[key] = val
+
+		if i%1000 == 0 {
+			if err := tree.Commit(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	check(t, tree, want)
+
+	if err := tree.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tree = open(t, path)
+	defer tree.Close()
+
+	check(t, tree, want)
+}
+
+func TestRollback(t *testing.T) {
+	tree := open(t, filepath.Join(t.TempDir(), "tree"))
+	defer tree.Close()
+
+	want := map[string]string{}
+
+	for i := 0; i < 500; i++ {
+		key := fmt.Sprintf("%04d", i)
+		tree.Insert([]byte(key), []byte(key))
+		want[key] = key
+	}
+
+	tree.Commit()
+
+	// Enough inserts to split nodes, all of which must be undone
+	for i := 0; i < 2000; i++ {
+		tree.Insert([]byte(fmt.Sprintf("%04d", i)), []byte("changed"))
+	}
+
+	if err := tree.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	check(t, tree, want)
+}
+
+func TestScanRange(t *testing.T) {
+	tree := open(t, filepath.Join(t.TempDir(), "tree"))
+	defer tree.Close()
+
+	for i := 0; i < 1000; i += 2 {
+		key := []byte(fmt.Sprintf("%04d", i))
+		tree.Insert(key, key)
+	}
+
+	cases := []struct {
+		lo, hi     string
+		first, end int
+	}{
+		{"0100", "0110", 100, 112},
+		{"0101", "0109", 102, 110},
+		{"", "0004", 0, 6},
+		{"0995", "", 996, 1000},
+		{"0500", "0400", 0, 0},
+	}
+
+	for _, c := range cases {
+		var hi []byte
+
+		if c.hi != "" {
+			hi = []byte(c.hi)
+		}
+
+		got := []string{}
+		tree.Scan([]byte(c.lo), hi, func(key, val []byte) bool {
+			got = append(got, string(key))
+			return true
+		})
+
+		want := []string{}
+
+		for i := c.first; i < c.end; i += 2 {
+			want = append(want, fmt.Sprintf("%04d", i))
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("Scan(%q, %q): got %v, want %v", c.lo, c.hi, got, want)
+		}
+	}
+
+	n := 0
+	tree.Scan(nil, nil, func(key, val []byte) bool {
+		n++
+		return n < 300
+	})
+
+	if n != 300 {
+		t.Errorf("Scan stopped after %d keys, want 300", n)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tree := open(t, filepath.Join(t.TempDir(), "tree"))
+	defer tree.Close()
+
+	if tree.Insert(nil, []byte("v")) != ErrKeySize || tree.Insert(make([]byte, MaxKeySize+1), nil) != ErrKeySize {
+		t.Error("accepted an empty or oversized key")
+	}
+
+	if tree.Insert([]byte("k"), make([]byte, MaxValueSize+1)) != ErrValueSize {
+		t.Error("accepted an oversized value")
+	}
+
+	// Entries of the maximum size must still split into pages that fit
+	want := map[string]string{}
+
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("%0*d", MaxKeySize, i)
+		val := string(bytes.Repeat([]byte{byte('a' + i%26)}, MaxValueSize))
+
+		if err := tree.Insert([]byte(key), []byte(val)); err != nil {
+			t.Fatal(err)
+		}
+
+		want[key] = val
+	}
+
+	check(t, tree, want)
+}
@@ -0,0 +1,361 @@
+// B-tree where every node holds between degree-1 and 2*degree-1 keys (except
+// the root) and all leaves are at the same depth. Nodes are allocated at
+// their full capacity and never grow, so a node maps onto a fixed-size page
+// once keys and values have a fixed encoded size.
+
+package btree
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+const DefaultDegree = 32
+
+type node[K cmp.Ordered, V any] struct {
+	keys []K
+	vals []V
+	// Empty for leaves, otherwise one more than the number of keys
+	children []*node[K, V]
+}
+
+type BTree[K cmp.Ordered, V any] struct {
+	root   *node[K, V]
+	degree int
+	length int
+}
+
+func New[K cmp.Ordered, V any]() *BTree[K, V] {
+	return NewWithDegree[K, V](DefaultDegree)
+}
+
+// Creates a tree whose nodes hold at most 2*degree-1 keys. The degree is at
+// least 2.
+func NewWithDegree[K cmp.Ordered, V any](degree int) *BTree[K, V] {
+	return &BTree[K, V]{degree: max(degree, 2)}
+}
+
+func (t *BTree[K, V]) Degree() int {
+	return t.degree
+}
+
+// Maximum number of keys in a node.
+func (t *BTree[K, V]) MaxKeys() int {
+	return 2*t.degree - 1
+}
+
+func (t *BTree[K, V]) Len() int {
+	return t.length
+}
+
+func (t *BTree[K, V]) newNode(leaf bool) *node[K, V] {
+	n := &node[K, V]{
+		keys: make([]K, 0, t.MaxKeys()),
+		vals: make([]V, 0, t.MaxKeys()),
+	}
+
+	if !leaf {
+		n.children = make([]*node[K, V], 0, t.MaxKeys()+1)
+	}
+
+	return n
+}
+
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+func (t *BTree[K, V]) Lookup(key K) (V, bool) {
+	n := t.root
+
+	for n != nil {
+		i, found := slices.BinarySearch(n.keys, key)
+
+		if found {
+			return n.vals[i], true
+		}
+
+		if n.leaf() {
+			break
+		}
+
+		n = n.children[i]
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (t *BTree[K, V]) Add(key K, val V) {
+	if t.root == nil {
+		t.root = t.newNode(true)
+	}
+
+	// Full nodes are split on the way down, so the parent always has room for
+	// the middle key. The root is split by giving it a new parent.
+	if len(t.root.keys) == t.MaxKeys() {
+		root := t.newNode(false)
+		root.children = append(root.children, t.root)
+		t.splitChild(root, 0)
+		t.root = root
+	}
+
+	n := t.root
+
+	for {
+		i, found := slices.BinarySearch(n.keys, key)
+
+		if found {
+			n.vals[i] = val
+			return
+		}
+
+		if n.leaf() {
+			n.keys = slices.Insert(n.keys, i, key)
+			n.vals = slices.Insert(n.vals, i, val)
+			t.length++
+			return
+		}
+
+		if len(n.children[i].keys) == t.MaxKeys() {
+			t.splitChild(n, i)
+
+			if key == n.keys[i] {
+				n.vals[i] = val
+				return
+			}
+
+			if key > n.keys[i] {
+				i++
+			}
+		}
+
+		n = n.children[i]
+	}
+}
+
+// Splits the full child i of n around its middle key, which moves up into n.
+func (t *BTree[K, V]) splitChild(n *node[K, V], i int) {
+	mid := t.degree - 1
+	child := n.children[i]
+	right := t.newNode(child.leaf())
+
+	right.keys = append(right.keys, child.keys[mid+1:]...)
+	right.vals = append(right.vals, child.vals[mid+1:]...)
+
+	if !child.leaf() {
+		right.children = append(right.children, child.children[mid+1:]...)
+		clear(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+
+	n.keys = slices.Insert(n.keys, i, child.keys[mid])
+	n.vals = slices.Insert(n.vals, i, child.vals[mid])
+	n.children = slices.Insert(n.children, i+1, right)
+
+	clear(child.keys[mid:])
+	clear(child.vals[mid:])
+	child.keys = child.keys[:mid]
+	child.vals = child.vals[:mid]
+}
+
+// Removes the key and returns its value, or false if it isn't in the tree.
+func (t *BTree[K, V]) Delete(key K) (V, bool) {
+	if t.root == nil {
+		var zero V
+		return zero, false
+	}
+
+	val, ok := t.delete(t.root, key)
+
+	if ok {
+		t.length--
+	}
+
+	// The root loses its last key when its only two children are merged
+	if len(t.root.keys) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+
+	return val, ok
+}
+
+// Deletes key from the subtree of n, which has at least degree keys unless it
+// is the root, so a key can be taken from it without another pass.
+func (t *BTree[K, V]) delete(n *node[K, V], key K) (V, bool) {
+	i, found := slices.BinarySearch(n.keys, key)
+
+	if n.leaf() {
+		if !found {
+			var zero V
+			return zero, false
+		}
+
+		val := n.vals[i]
+		n.keys = slices.Delete(n.keys, i, i+1)
+		n.vals = slices.Delete(n.vals, i, i+1)
+
+		return val, true
+	}
+
+	if found {
+		val := n.vals[i]
+
+		// Replace the key with its predecessor or successor if either child
+		// can spare one, otherwise merge the children around it
+		if left := n.children[i]; len(left.keys) >= t.degree {
+			pred := left
+
+			for !pred.leaf() {
+				pred = pred.children[len(pred.children)-1]
+			}
+
+			last := len(pred.keys) - 1
+			n.keys[i], n.vals[i] = pred.keys[last], pred.vals[last]
+			t.delete(left, n.keys[i])
+		} else if right := n.children[i+1]; len(right.keys) >= t.degree {
+			succ := right
+
+			for !succ.leaf() {
+				succ = succ.children[0]
+			}
+
+			n.keys[i], n.vals[i] = succ.keys[0], succ.vals[0]
+			t.delete(right, n.keys[i])
+		} else {
+			t.merge(n, i)
+			t.delete(left, key)
+		}
+
+		return val, true
+	}
+
+	if len(n.children[i].keys) < t.degree {
+		i = t.fill(n, i)
+	}
+
+	return t.delete(n.children[i], key)
+}
+
+// Gives child i of n at least degree keys, by borrowing from a sibling or by
+// merging with one. Returns the new index of the child.
+func (t *BTree[K, V]) fill(n *node[K, V], i int) int {
+	if i > 0 && len(n.children[i-1].keys) >= t.degree {
+		child, sib := n.children[i], n.children[i-1]
+		last := len(sib.keys) - 1
+
+		// Rotate the separator down into the child and the sibling's last key
+		// up into the separator
+		child.keys = slices.Insert(child.keys, 0, n.keys[i-1])
+		child.vals = slices.Insert(child.vals, 0, n.vals[i-1])
+		n.keys[i-1], n.vals[i-1] = sib.keys[last], sib.vals[last]
+		sib.keys = slices.Delete(sib.keys, last, last+1)
+		sib.vals = slices.Delete(sib.vals, last, last+1)
+
+		if !child.leaf() {
+			lastChild := len(sib.children) - 1
+			child.children = slices.Insert(child.children, 0, sib.children[lastChild])
+			sib.children = slices.Delete(sib.children, lastChild, lastChild+1)
+		}
+
+		return i
+	}
+
+	if i < len(n.keys) && len(n.children[i+1].keys) >= t.degree {
+		child, sib := n.children[i], n.children[i+1]
+
+		child.keys = append(child.keys, n.keys[i])
+		child.vals = append(child.vals, n.vals[i])
+		n.keys[i], n.vals[i] = sib.keys[0], sib.vals[0]
+		sib.keys = slices.Delete(sib.keys, 0, 1)
+		sib.vals = slices.Delete(sib.vals, 0, 1)
+
+		if !child.leaf() {
+			child.children = append(child.children, sib.children[0])
+			sib.children = slices.Delete(sib.children, 0, 1)
+		}
+
+		return i
+	}
+
+	if i == len(n.keys) {
+		i--
+	}
+
+	t.merge(n, i)
+	return i
+}
+
+// Merges child i+1 of n and the key between them into child i. Both children
+// have degree-1 keys, so the result is exactly full.
+func (t *BTree[K, V]) merge(n *node[K, V], i int) {
+	child, sib := n.children[i], n.children[i+1]
+
+	child.keys = append(append(child.keys, n.keys[i]), sib.keys...)
+	child.vals = append(append(child.vals, n.vals[i]), sib.vals...)
+	child.children = append(child.children, sib.children...)
+
+	n.keys = slices.Delete(n.keys, i, i+1)
+	n.vals = slices.Delete(n.vals, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+// Iterates over all keys in order.
+func (t *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root != nil {
+			t.root.all(yield)
+		}
+	}
+}
+
+// Returns false if yield stopped the iteration.
+func (n *node[K, V]) all(yield func(K, V) bool) bool {
+	for i := range n.keys {
+		if !n.leaf() && !n.children[i].all(yield) {
+			return false
+		}
+
+		if !yield(n.keys[i], n.vals[i]) {
+			return false
+		}
+	}
+
+	return n.leaf() || n.children[len(n.keys)].all(yield)
+}
+
+// Iterates over the keys in [lo, hi] in order.
+func (t *BTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root != nil {
+			t.root.rangeKeys(lo, hi, yield)
+		}
+	}
+}
+
+// Returns false once the iteration is done, either because yield stopped it
+// or because a key past hi was reached.
+func (n *node[K, V]) rangeKeys(lo, hi K, yield func(K, V) bool) bool {
+	i, _ := slices.BinarySearch(n.keys, lo)
+
+	for ; i <= len(n.keys); i++ {
+		if !n.leaf() && !n.children[i].rangeKeys(lo, hi, yield) {
+			return false
+		}
+
+		if i == len(n.keys) {
+			break
+		}
+
+		if n.keys[i] > hi || !yield(n.keys[i], n.vals[i]) {
+			return false
+		}
+	}
+
+	return true
+}
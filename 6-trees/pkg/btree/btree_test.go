@@ -0,0 +1,199 @@
+package btree
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Checks the node sizes, key order within (lo, hi) and that all leaves are at
+// the same depth. Returns the depth of the leaves below n and the number of
+// keys in its subtree.
+func checkNode(t *testing.T, tree *BTree[int, int], n *node[int, int], lo, hi int, root bool) (int, int) {
+	t.Helper()
+
+	if len(n.keys) > tree.MaxKeys() || (!root && len(n.keys) < tree.degree-1) || len(n.keys) == 0 {
+		t.Fatalf("node has %d keys with degree %d", len(n.keys), tree.degree)
+	}
+
+	if len(n.vals) != len(n.keys) {
+		t.Fatalf("node has %d keys but %d values", len(n.keys), len(n.vals))
+	}
+
+	if cap(n.keys) != tree.MaxKeys() {
+		t.Fatalf("node keys grew to capacity %d", cap(n.keys))
+	}
+
+	prev := lo
+
+	for _, key := range n.keys {
+		if key <= prev || key >= hi {
+			t.Fatalf("key %d out of order in (%d, %d)", key, prev, hi)
+		}
+
+		prev = key
+	}
+
+	if n.leaf() {
+		return 0, len(n.keys)
+	}
+
+	if len(n.children) != len(n.keys)+1 {
+		t.Fatalf("node has %d keys but %d children", len(n.keys), len(n.children))
+	}
+
+	depth := -1
+	count := len(n.keys)
+
+	for i, child := range n.children {
+		childLo, childHi := lo, hi
+
+		if i > 0 {
+			childLo = n.keys[i-1]
+		}
+
+		if i < len(n.keys) {
+			childHi = n.keys[i]
+		}
+
+		d, c := checkNode(t, tree, child, childLo, childHi, false)
+
+		if depth != -1 && d != depth {
+			t.Fatalf("leaves at depths %d and %d", depth, d+1)
+		}
+
+		depth = d
+		count += c
+	}
+
+	return depth + 1, count
+}
+
+func check(t *testing.T, tree *BTree[int, int], want map[int]int) {
+	t.Helper()
+
+	if tree.root != nil {
+		if _, count := checkNode(t, tree, tree.root, -1<<62, 1<<62, true); count != len(want) {
+			t.Fatalf("tree holds %d keys, want %d", count, len(want))
+		}
+	} else if len(want) != 0 {
+		t.Fatalf("empty tree, want %d keys", len(want))
+	}
+
+	if tree.Len() != len(want) {
+		t.Fatalf("Len: got %d, want %d", tree.Len(), len(want))
+	}
+
+	keys := []int{}
+
+	for key, val := range tree.All() {
+		if val != want[key] {
+			t.Fatalf("key %d: got value %d, want %d", key, val, want[key])
+		}
+
+		keys = append(keys, key)
+	}
+
+	if !slices.Equal(keys, slices.Sorted(maps.Keys(want))) {
+		t.Fatalf("All: got %v", keys)
+	}
+}
+
+func TestAgainstMap(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, 32} {
+		rng := rand.New(rand.NewSource(int64(degree)))
+		tree := NewWithDegree[int, int](degree)
+		want := map[int]int{}
+
+		for i := 0; i < 20000; i++ {
+			key := rng.Intn(1000)
+
+			// Phases that grow and shrink the tree so that it gets both deep
+			// and empty again
+			if rng.Intn(10) < 6-4*(i/5000%2) {
+				tree.Add(key, i)
+				want[key] = i
+			} else {
+				v, ok := tree.Delete(key)
+				wv, wok := want[key]
+
+				if v != wv || ok != wok {
+					t.Fatalf("degree %d: Delete(%d) got %d, %t, want %d, %t", degree, key, v, ok, wv, wok)
+				}
+
+				delete(want, key)
+			}
+
+			wv, wok := want[key]
+
+			if v, ok := tree.Lookup(key); v != wv || ok != wok {
+				t.Fatalf("degree %d: Lookup(%d) got %d, %t, want %d, %t", degree, key, v, ok, wv, wok)
+			}
+
+			if i%250 == 0 {
+				check(t, tree, want)
+			}
+		}
+
+		check(t, tree, want)
+
+		for key := range want {
+			tree.Delete(key)
+		}
+
+		check(t, tree, map[int]int{})
+	}
+}
+
+func TestRange(t *testing.T) {
+	tree := NewWithDegree[int, int](2)
+
+	for i := 0; i < 200; i += 2 {
+		tree.Add(i, -i)
+	}
+
+	for lo := -3; lo < 205; lo += 7 {
+		for hi := lo - 2; hi < 205; hi += 11 {
+			want := []int{}
+
+			for k := max(lo, 0); k <= hi && k < 200; k++ {
+				if k%2 == 0 {
+					want = append(want, k)
+				}
+			}
+
+			got := []int{}
+
+			for key, val := range tree.Range(lo, hi) {
+				if val != -key {
+					t.Fatalf("key %d has value %d", key, val)
+				}
+
+				got = append(got, key)
+			}
+
+			if !slices.Equal(got, want) {
+				t.Fatalf("Range(%d, %d): got %v, want %v", lo, hi, got, want)
+			}
+		}
+	}
+
+	n := 0
+
+	for range tree.Range(0, 200) {
+		n++
+
+		if n == 10 {
+			break
+		}
+	}
+
+	if n != 10 {
+		t.Errorf("Range stopped after %d keys", n)
+	}
+
+	if _, ok := New[int, int]().Delete(1); ok {
+		t.Error("Delete on empty tree succeeded")
+	}
+}
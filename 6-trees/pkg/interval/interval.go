@@ -0,0 +1,241 @@
+// Interval tree: an AVL tree of closed intervals ordered by their start, where
+// each node also stores the largest end in its subtree. Subtrees whose
+// largest end is before the query, or that start after it, are skipped, so
+// finding the k intervals that overlap a query takes O(min(n, k log n)).
+
+package interval
+
+import (
+	"cmp"
+)
+
+type Interval[T cmp.Ordered, V any] struct {
+	Lo  T
+	Hi  T
+	Val V
+}
+
+// Returns true if the interval shares at least one point with [lo, hi].
+func (iv Interval[T, V]) Overlaps(lo, hi T) bool {
+	return iv.Lo <= hi && lo <= iv.Hi
+}
+
+type node[T cmp.Ordered, V any] struct {
+	iv     Interval[T, V]
+	left   *node[T, V]
+	right  *node[T, V]
+	height int
+	// Largest Hi in the subtree
+	maxHi T
+}
+
+type IntervalTree[T cmp.Ordered, V any] struct {
+	root   *node[T, V]
+	length int
+}
+
+func New[T cmp.Ordered, V any]() *IntervalTree[T, V] {
+	return &IntervalTree[T, V]{}
+}
+
+func (t *IntervalTree[T, V]) Len() int {
+	return t.length
+}
+
+func compareIntervals[T cmp.Ordered](lo1, hi1, lo2, hi2 T) int {
+	if c := cmp.Compare(lo1, lo2); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(hi1, hi2)
+}
+
+func (n *node[T, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func (n *node[T, V]) update() {
+	n.height = max(n.left.getHeight(), n.right.getHeight()) + 1
+	n.maxHi = n.iv.Hi
+
+	if n.left != nil {
+		n.maxHi = max(n.maxHi, n.left.maxHi)
+	}
+
+	if n.right != nil {
+		n.maxHi = max(n.maxHi, n.right.maxHi)
+	}
+}
+
+func (n *node[T, V]) rotateLeft() *node[T, V] {
+	right := n.right
+	n.right = right.left
+	right.left = n
+
+	n.update()
+	right.update()
+
+	return right
+}
+
+func (n *node[T, V]) rotateRight() *node[T, V] {
+	left := n.left
+	n.left = left.right
+	left.right = n
+
+	n.update()
+	left.update()
+
+	return left
+}
+
+func (n *node[T, V]) rebalance() *node[T, V] {
+	n.update()
+
+	switch b := n.left.getHeight() - n.right.getHeight(); {
+	case b > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+
+		return n.rotateRight()
+	case b < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+
+		return n.rotateLeft()
+	}
+
+	return n
+}
+
+// Adds [lo, hi] with the given value, replacing the value if the interval is
+// already in the tree. Intervals with lo > hi are ignored.
+func (t *IntervalTree[T, V]) Insert(lo, hi T, val V) {
+	if lo > hi {
+		return
+	}
+
+	var added bool
+	t.root, added = t.root.insert(Interval[T, V]{lo, hi, val})
+
+	if added {
+		t.length++
+	}
+}
+
+func (n *node[T, V]) insert(iv Interval[T, V]) (*node[T, V], bool) {
+	if n == nil {
+		leaf := &node[T, V]{iv: iv}
+		leaf.update()
+
+		return leaf, true
+	}
+
+	var added bool
+
+	switch c := compareIntervals(iv.Lo, iv.Hi, n.iv.Lo, n.iv.Hi); {
+	case c == 0:
+		n.iv.Val = iv.Val
+		return n, false
+	case c < 0:
+		n.left, added = n.left.insert(iv)
+	default:
+		n.right, added = n.right.insert(iv)
+	}
+
+	return n.rebalance(), added
+}
+
+// Removes [lo, hi] and returns its value, or false if it isn't in the tree.
+func (t *IntervalTree[T, V]) Delete(lo, hi T) (V, bool) {
+	var val V
+	var ok bool
+	t.root, val, ok = t.root.delete(lo, hi)
+
+	if ok {
+		t.length--
+	}
+
+	return val, ok
+}
+
+func (n *node[T, V]) delete(lo, hi T) (*node[T, V], V, bool) {
+	if n == nil {
+		var zero V
+		return nil, zero, false
+	}
+
+	var val V
+	var ok bool
+
+	switch c := compareIntervals(lo, hi, n.iv.Lo, n.iv.Hi); {
+	case c < 0:
+		n.left, val, ok = n.left.delete(lo, hi)
+	case c > 0:
+		n.right, val, ok = n.right.delete(lo, hi)
+	default:
+		val, ok = n.iv.Val, true
+
+		if n.left == nil {
+			return n.right, val, true
+		}
+
+		if n.right == nil {
+			return n.left, val, true
+		}
+
+		succ := n.right
+
+		for succ.left != nil {
+			succ = succ.left
+		}
+
+		n.iv = succ.iv
+		n.right, _, _ = n.right.delete(succ.iv.Lo, succ.iv.Hi)
+	}
+
+	if !ok {
+		return n, val, false
+	}
+
+	return n.rebalance(), val, true
+}
+
+// Returns the intervals that contain p, ordered by start.
+func (t *IntervalTree[T, V]) QueryPoint(p T) []Interval[T, V] {
+	return t.QueryRange(p, p)
+}
+
+// Returns the intervals that overlap [lo, hi], ordered by start.
+func (t *IntervalTree[T, V]) QueryRange(lo, hi T) []Interval[T, V] {
+	res := []Interval[T, V]{}
+	t.root.query(lo, hi, &res)
+
+	return res
+}
+
+func (n *node[T, V]) query(lo, hi T, res *[]Interval[T, V]) {
+	// Nothing in the subtree reaches lo
+	if n == nil || n.maxHi < lo {
+		return
+	}
+
+	n.left.query(lo, hi, res)
+
+	// Everything to the right starts after hi too
+	if n.iv.Lo > hi {
+		return
+	}
+
+	if n.iv.Overlaps(lo, hi) {
+		*res = append(*res, n.iv)
+	}
+
+	n.right.query(lo, hi, res)
+}
@@ -0,0 +1,137 @@
+package interval
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type span struct {
+	lo, hi int
+}
+
+// Brute force overlap query over a set of intervals.
+func overlapping(set map[span]int, lo, hi int) []Interval[int, int] {
+	res := []Interval[int, int]{}
+
+	for s, val := range set {
+		if s.lo <= hi && lo <= s.hi {
+			res = append(res, Interval[int, int]{s.lo, s.hi, val})
+		}
+	}
+
+	slices.SortFunc(res, func(a, b Interval[int, int]) int {
+		return compareIntervals(a.Lo, a.Hi, b.Lo, b.Hi)
+	})
+
+	return res
+}
+
+// Checks the AVL balance, order and the stored max ends. Returns the height.
+func check(t *testing.T, n *node[int, int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+
+	left, right := check(t, n.left), check(t, n.right)
+
+	if left-right > 1 || right-left > 1 {
+		t.Fatalf("unbalanced at [%d, %d]", n.iv.Lo, n.iv.Hi)
+	}
+
+	maxHi := n.iv.Hi
+
+	for _, child := range []*node[int, int]{n.left, n.right} {
+		if child != nil {
+			maxHi = max(maxHi, child.maxHi)
+		}
+	}
+
+	if n.maxHi != maxHi || n.height != max(left, right)+1 {
+		t.Fatalf("wrong max end or height at [%d, %d]", n.iv.Lo, n.iv.Hi)
+	}
+
+	if n.left != nil && compareIntervals(n.left.iv.Lo, n.left.iv.Hi, n.iv.Lo, n.iv.Hi) >= 0 {
+		t.Fatalf("left child out of order at [%d, %d]", n.iv.Lo, n.iv.Hi)
+	}
+
+	if n.right != nil && compareIntervals(n.right.iv.Lo, n.right.iv.Hi, n.iv.Lo, n.iv.Hi) <= 0 {
+		t.Fatalf("right child out of order at [%d, %d]", n.iv.Lo, n.iv.Hi)
+	}
+
+	return n.height
+}
+
+func TestAgainstBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := New[int, int]()
+	set := map[span]int{}
+
+	for i := 0; i < 5000; i++ {
+		lo := rng.Intn(200)
+		s := span{lo, lo + rng.Intn(30)}
+
+		if rng.Intn(3) == 0 {
+			val, ok := tree.Delete(s.lo, s.hi)
+			want, wantOk := set[s]
+
+			if ok != wantOk || val != want {
+				t.Fatalf("Delete(%d, %d): got %d, %t, want %d, %t", s.lo, s.hi, val, ok, want, wantOk)
+			}
+
+			delete(set, s)
+		} else {
+			tree.Insert(s.lo, s.hi, i)
+			set[s] = i
+		}
+
+		if i%50 != 0 {
+			continue
+		}
+
+		check(t, tree.root)
+
+		if tree.Len() != len(set) {
+			t.Fatalf("Len: got %d, want %d", tree.Len(), len(set))
+		}
+
+		p := rng.Intn(240) - 5
+
+		if got, want := tree.QueryPoint(p), overlapping(set, p, p); !slices.Equal(got, want) {
+			t.Fatalf("QueryPoint(%d): got %v, want %v", p, got, want)
+		}
+
+		qlo := rng.Intn(240) - 5
+		qhi := qlo + rng.Intn(20)
+
+		if got, want := tree.QueryRange(qlo, qhi), overlapping(set, qlo, qhi); !slices.Equal(got, want) {
+			t.Fatalf("QueryRange(%d, %d): got %v, want %v", qlo, qhi, got, want)
+		}
+	}
+}
+
+func TestEdges(t *testing.T) {
+	tree := New[float64, string]()
+	tree.Insert(1, 2, "a")
+	tree.Insert(2, 3, "b")
+	tree.Insert(5, 4, "ignored")
+
+	if tree.Len() != 2 {
+		t.Fatalf("got %d intervals, want 2", tree.Len())
+	}
+
+	// Closed intervals touch at their ends
+	if got := tree.QueryPoint(2); len(got) != 2 {
+		t.Errorf("QueryPoint(2): got %v", got)
+	}
+
+	if got := tree.QueryRange(3.5, 10); len(got) != 0 {
+		t.Errorf("QueryRange(3.5, 10): got %v", got)
+	}
+
+	if _, ok := tree.Delete(1, 3); ok {
+		t.Error("deleted an interval that isn't in the tree")
+	}
+}
@@ -0,0 +1,417 @@
+// Page file: a file split into fixed-size pages with a free list, a buffer
+// pool of cached pages and a write-ahead log that makes each commit atomic.
+//
+// Page 0 is the header. Changed pages stay in memory until Commit, which
+// first writes them all to the log and syncs it, and only then writes them to
+// the file. If the process dies half way through writing the file, the log is
+// replayed on the next Open; if it dies before the log is complete, the log is
+// ignored and the file still holds the previous commit.
+
+package pagefile
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"slices"
+)
+
+const PageSize = 4096
+
+type PageID uint32
+
+const (
+	magic    = 0x46504233
+	logMagic = 0x4c415757
+
+	// Header fields
+	offMagic     = 0
+	offPageCount = 4
+	offFreeHead  = 8
+	offRoot      = 12
+)
+
+var ErrBadPage = errors.New("page out of range")
+
+type Page struct {
+	ID   PageID
+	Data []byte
+	pins int
+	// Changed since the last commit
+	dirty bool
+	// Position in the LRU list while the page is clean and unpinned
+	elem *list.Element
+}
+
+type Pager struct {
+	file     *os.File
+	log      *os.File
+	pages    map[PageID]*Page
+	lru      *list.List
+	capacity int
+	header   *Page
+}
+
+// Opens or creates the page file at path, keeping up to capacity unused
+// pages cached. The log is kept next to it in path + "-wal".
+func Open(path string, capacity int) (*Pager, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := os.OpenFile(path+"-wal", os.O_RDWR|os.O_CREATE, 0o644)
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	p := &Pager{
+		file:     file,
+		log:      log,
+		pages:    map[PageID]*Page{},
+		lru:      list.New(),
+		capacity: max(capacity, 1),
+	}
+
+	if err := p.init(); err != nil {
+		file.Close()
+		log.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *Pager) init() error {
+	if err := p.recover(); err != nil {
+		return err
+	}
+
+	info, err := p.file.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	header := &Page{ID: 0, Data: make([]byte, PageSize), pins: 1}
+
+	if info.Size() == 0 {
+		binary.LittleEndian.PutUint32(header.Data[offMagic:], magic)
+		binary.LittleEndian.PutUint32(header.Data[offPageCount:], 1)
+
+		if _, err := p.file.WriteAt(header.Data, 0); err != nil {
+			return err
+		}
+
+		if err := p.file.Sync(); err != nil {
+			return err
+		}
+	} else if _, err := p.file.ReadAt(header.Data, 0); err != nil {
+		return err
+	}
+
+	if binary.LittleEndian.Uint32(header.Data[offMagic:]) != magic {
+		return errors.New("not a page file")
+	}
+
+	// The header stays pinned so it is never evicted
+	p.header = header
+	p.pages[0] = header
+
+	return nil
+}
+
+func (p *Pager) headerField(off int) uint32 {
+	return binary.LittleEndian.Uint32(p.header.Data[off:])
+}
+
+func (p *Pager) setHeaderField(off int, v uint32) {
+	binary.LittleEndian.PutUint32(p.header.Data[off:], v)
+	p.header.dirty = true
+}
+
+// Number of pages in the file, including the header and free pages.
+func (p *Pager) PageCount() uint32 {
+	return p.headerField(offPageCount)
+}
+
+// Returns the page ID stored in the header for the user of the file, 0 until
+// it is set.
+func (p *Pager) Root() PageID {
+	return PageID(p.headerField(offRoot))
+}
+
+func (p *Pager) SetRoot(id PageID) {
+	p.setHeaderField(offRoot, uint32(id))
+}
+
+// Returns the page pinned in the buffer pool. It must be released with Release
+// once it is no longer used.
+func (p *Pager) Get(id PageID) (*Page, error) {
+	if id == 0 || uint32(id) >= p.PageCount() {
+		return nil, fmt.Errorf("%w: %d", ErrBadPage, id)
+	}
+
+	if page, ok := p.pages[id]; ok {
+		if page.elem != nil {
+			p.lru.Remove(page.elem)
+			page.elem = nil
+		}
+
+		page.pins++
+		return page, nil
+	}
+
+	page := &Page{ID: id, Data: make([]byte, PageSize), pins: 1}
+
+	if _, err := p.file.ReadAt(page.Data, int64(id)*PageSize); err != nil {
+		return nil, err
+	}
+
+	p.pages[id] = page
+	p.evict()
+
+	return page, nil
+}
+
+func (p *Pager) Release(page *Page) {
+	page.pins--
+
+	// Pages dropped by a rollback are no longer in the pool
+	if p.pages[page.ID] != page {
+		return
+	}
+
+	if page.pins == 0 && !page.dirty {
+		page.elem = p.lru.PushFront(page)
+		p.evict()
+	}
+}
+
+// Marks a pinned page as changed, so that it is written on Commit.
+func (p *Pager) MarkDirty(page *Page) {
+	page.dirty = true
+}
+
+// Drops least recently used clean pages until the pool is within capacity.
+// Pinned and dirty pages are never dropped, so the pool can grow past its
+// capacity while many pages are in use.
+func (p *Pager) evict() {
+	for len(p.pages) > p.capacity && p.lru.Len() > 0 {
+		page := p.lru.Remove(p.lru.Back()).(*Page)
+		page.elem = nil
+		delete(p.pages, page.ID)
+	}
+}
+
+// Returns a zeroed, pinned and dirty page, reusing a freed page if there is
+// one.
+func (p *Pager) Alloc() (*Page, error) {
+	if free := PageID(p.headerField(offFreeHead)); free != 0 {
+		page, err := p.Get(free)
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.setHeaderField(offFreeHead, binary.LittleEndian.Uint32(page.Data))
+		clear(page.Data)
+		page.dirty = true
+
+		return page, nil
+	}
+
+	id := PageID(p.PageCount())
+	p.setHeaderField(offPageCount, uint32(id)+1)
+
+	page := &Page{ID: id, Data: make([]byte, PageSize), pins: 1, dirty: true}
+	p.pages[id] = page
+
+	return page, nil
+}
+
+// Puts the page on the free list. It must not be pinned.
+func (p *Pager) Free(id PageID) error {
+	page, err := p.Get(id)
+
+	if err != nil {
+		return err
+	}
+
+	clear(page.Data)
+	binary.LittleEndian.PutUint32(page.Data, p.headerField(offFreeHead))
+	p.setHeaderField(offFreeHead, uint32(id))
+	page.dirty = true
+	p.Release(page)
+
+	return nil
+}
+
+func (p *Pager) dirtyPages() []*Page {
+	pages := []*Page{}
+
+	for _, page := range p.pages {
+		if page.dirty {
+			pages = append(pages, page)
+		}
+	}
+
+	slices.SortFunc(pages, func(a, b *Page) int {
+		return int(a.ID) - int(b.ID)
+	})
+
+	return pages
+}
+
+// Makes all changes since the last commit durable.
+func (p *Pager) Commit() error {
+	pages := p.dirtyPages()
+
+	if len(pages) == 0 {
+		return nil
+	}
+
+	if err := p.writeLog(pages); err != nil {
+		return err
+	}
+
+	if err := p.apply(pages); err != nil {
+		return err
+	}
+
+	for _, page := range pages {
+		page.dirty = false
+
+		if page.pins == 0 {
+			page.elem = p.lru.PushFront(page)
+		}
+	}
+
+	p.evict()
+	return nil
+}
+
+// Discards all changes since the last commit. Pages obtained before the
+// rollback must not be used afterwards.
+func (p *Pager) Rollback() error {
+	for _, page := range p.dirtyPages() {
+		if page == p.header {
+			if _, err := p.file.ReadAt(page.Data, 0); err != nil {
+				return err
+			}
+
+			page.dirty = false
+			continue
+		}
+
+		delete(p.pages, page.ID)
+	}
+
+	return nil
+}
+
+// Discards uncommitted changes and closes the file.
+func (p *Pager) Close() error {
+	err := p.Rollback()
+
+	return errors.Join(err, p.file.Close(), p.log.Close())
+}
+
+// Log layout: magic, page count, then the ID and contents of each page, then
+// a CRC of everything before it.
+func (p *Pager) writeLog(pages []*Page) error {
+	buf := make([]byte, 8, 12+len(pages)*(4+PageSize))
+	binary.LittleEndian.PutUint32(buf, logMagic)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(len(pages)))
+
+	for _, page := range pages {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(page.ID))
+		buf = append(buf, page.Data...)
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+
+	if _, err := p.log.WriteAt(buf, 0); err != nil {
+		return err
+	}
+
+	return p.log.Sync()
+}
+
+// Writes the pages to the file and clears the log once they are on disk.
+func (p *Pager) apply(pages []*Page) error {
+	for _, page := range pages {
+		if _, err := p.file.WriteAt(page.Data, int64(page.ID)*PageSize); err != nil {
+			return err
+		}
+	}
+
+	if err := p.file.Sync(); err != nil {
+		return err
+	}
+
+	if err := p.log.Truncate(0); err != nil {
+		return err
+	}
+
+	return p.log.Sync()
+}
+
+// Replays a complete log left by a commit that didn't finish writing the
+// file. An incomplete log means the commit never happened and is dropped.
+func (p *Pager) recover() error {
+	buf, err := io.ReadAll(io.NewSectionReader(p.log, 0, 1<<40))
+
+	if err != nil {
+		return err
+	}
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	pages, ok := parseLog(buf)
+
+	if !ok {
+		pages = nil
+	}
+
+	return p.apply(pages)
+}
+
+func parseLog(buf []byte) ([]*Page, bool) {
+	if len(buf) < 12 || binary.LittleEndian.Uint32(buf) != logMagic {
+		return nil, false
+	}
+
+	count := int(binary.LittleEndian.Uint32(buf[4:]))
+
+	if len(buf) != 12+count*(4+PageSize) {
+		return nil, false
+	}
+
+	body := buf[:len(buf)-4]
+
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(buf[len(body):]) {
+		return nil, false
+	}
+
+	pages := make([]*Page, count)
+
+	for i := range pages {
+		entry := body[8+i*(4+PageSize):]
+		pages[i] = &Page{
+			ID:   PageID(binary.LittleEndian.Uint32(entry)),
+			Data: entry[4 : 4+PageSize],
+		}
+	}
+
+	return pages, true
+}
@@ -0,0 +1,228 @@
+package pagefile
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func open(t *testing.T, path string, capacity int) *Pager {
+	t.Helper()
+
+	p, err := Open(path, capacity)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+// Allocates a page filled with b and releases it.
+func alloc(t *testing.T, p *Pager, b byte) PageID {
+	t.Helper()
+
+	page, err := p.Alloc()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range page.Data {
+		page.Data[i] = b
+	}
+
+	p.Release(page)
+	return page.ID
+}
+
+// Checks that page id is filled with b.
+func expectPage(t *testing.T, p *Pager, id PageID, b byte) {
+	t.Helper()
+
+	page, err := p.Get(id)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer p.Release(page)
+
+	if !bytes.Equal(page.Data, bytes.Repeat([]byte{b}, PageSize)) {
+		t.Fatalf("page %d: got %d..., want %d", id, page.Data[0], b)
+	}
+}
+
+func TestCommitReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages")
+	p := open(t, path, 4)
+
+	ids := []PageID{}
+
+	for i := 0; i < 10; i++ {
+		ids = append(ids, alloc(t, p, byte(i+1)))
+	}
+
+	p.SetRoot(ids[3])
+
+	if err := p.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reading back through a pool smaller than the number of pages
+	for i, id := range ids {
+		expectPage(t, p, id, byte(i+1))
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p = open(t, path, 4)
+	defer p.Close()
+
+	if p.PageCount() != 11 || p.Root() != ids[3] {
+		t.Fatalf("got %d pages and root %d, want 11 and %d", p.PageCount(), p.Root(), ids[3])
+	}
+
+	for i, id := range ids {
+		expectPage(t, p, id, byte(i+1))
+	}
+
+	if _, err := p.Get(11); !errors.Is(err, ErrBadPage) {
+		t.Errorf("Get past the end: got %v", err)
+	}
+}
+
+func TestFreeList(t *testing.T) {
+	p := open(t, filepath.Join(t.TempDir(), "pages"), 8)
+	defer p.Close()
+
+	a, b, c := alloc(t, p, 1), alloc(t, p, 2), alloc(t, p, 3)
+
+	for _, id := range []PageID{a, c} {
+		if err := p.Free(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Freed pages are reused most recently freed first, and come back zeroed
+	for _, want := range []PageID{c, a} {
+		page, err := p.Alloc()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if page.ID != want || !bytes.Equal(page.Data, make([]byte, PageSize)) {
+			t.Fatalf("Alloc: got page %d, want zeroed page %d", page.ID, want)
+		}
+
+		p.Release(page)
+	}
+
+	if id := alloc(t, p, 4); id != b+2 {
+		t.Errorf("Alloc with empty free list: got page %d, want %d", id, b+2)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	p := open(t, filepath.Join(t.TempDir(), "pages"), 8)
+	defer p.Close()
+
+	a := alloc(t, p, 1)
+	p.SetRoot(a)
+
+	if err := p.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	page, _ := p.Get(a)
+	page.Data[0] = 9
+	p.MarkDirty(page)
+	p.Release(page)
+	alloc(t, p, 2)
+	p.SetRoot(0)
+
+	if err := p.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if p.PageCount() != 2 || p.Root() != a {
+		t.Fatalf("got %d pages and root %d after rollback", p.PageCount(), p.Root())
+	}
+
+	expectPage(t, p, a, 1)
+}
+
+func TestRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages")
+	p := open(t, path, 8)
+	a := alloc(t, p, 1)
+
+	if err := p.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Crash after the log is written but before the file is updated
+	page, _ := p.Get(a)
+	page.Data[0] = 2
+	p.MarkDirty(page)
+	p.Release(page)
+	b := alloc(t, p, 3)
+
+	if err := p.writeLog(p.dirtyPages()); err != nil {
+		t.Fatal(err)
+	}
+
+	p.file.Close()
+	p.log.Close()
+
+	p = open(t, path, 8)
+
+	if page, _ := p.Get(a); page.Data[0] != 2 || page.Data[1] != 1 {
+		t.Fatal("committed change to page wasn't replayed")
+	}
+
+	expectPage(t, p, b, 3)
+
+	// Crash while writing the log: the commit is lost, the file is intact
+	page, _ = p.Get(b)
+	page.Data[0] = 4
+	p.MarkDirty(page)
+	p.Release(page)
+	alloc(t, p, 5)
+
+	if err := p.writeLog(p.dirtyPages()); err != nil {
+		t.Fatal(err)
+	}
+
+	info, _ := p.log.Stat()
+	p.log.Truncate(info.Size() - 100)
+	p.file.Close()
+	p.log.Close()
+
+	p = open(t, path, 8)
+	defer p.Close()
+
+	if p.PageCount() != 3 {
+		t.Fatalf("got %d pages after torn commit, want 3", p.PageCount())
+	}
+
+	expectPage(t, p, b, 3)
+
+	if info, _ := os.Stat(path + "-wal"); info.Size() != 0 {
+		t.Error("log wasn't cleared after recovery")
+	}
+}
+
+func TestNotPageFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages")
+	os.WriteFile(path, []byte("hello"), 0o644)
+
+	if _, err := Open(path, 8); err == nil {
+		t.Error("opened a file that isn't a page file")
+	}
+}
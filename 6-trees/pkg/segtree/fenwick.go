@@ -0,0 +1,59 @@
+package segtree
+
+// Fenwick (binary indexed) tree with a user-supplied associative and
+// commutative combine function. It only answers prefix queries; a range
+// [lo, hi) can be derived from Prefix(hi) and Prefix(lo) when the combine
+// function has an inverse, e.g. subtraction for sums.
+type Fenwick[T any] struct {
+	nodes    []T
+	combine  func(a, b T) T
+	identity T
+}
+
+// Creates a Fenwick tree of n elements, all set to the identity.
+func NewFenwick[T any](n int, combine func(a, b T) T, identity T) *Fenwick[T] {
+	nodes := make([]T, n+1)
+
+	for i := range nodes {
+		nodes[i] = identity
+	}
+
+	return &Fenwick[T]{nodes, combine, identity}
+}
+
+// Builds a Fenwick tree over vals in O(n).
+func NewFenwickFrom[T any](vals []T, combine func(a, b T) T, identity T) *Fenwick[T] {
+	f := NewFenwick(len(vals), combine, identity)
+
+	for i, v := range vals {
+		f.nodes[i+1] = combine(f.nodes[i+1], v)
+
+		if parent := i + 1 + (i+1)&-(i+1); parent <= len(vals) {
+			f.nodes[parent] = combine(f.nodes[parent], f.nodes[i+1])
+		}
+	}
+
+	return f
+}
+
+func (f *Fenwick[T]) Len() int {
+	return len(f.nodes) - 1
+}
+
+// Combines val into the element at index i.
+func (f *Fenwick[T]) Add(i int, val T) {
+	for i++; i < len(f.nodes); i += i & -i {
+		f.nodes[i] = f.combine(f.nodes[i], val)
+	}
+}
+
+// Returns the combination of the first n elements.
+func (f *Fenwick[T]) Prefix(n int) T {
+	res := f.identity
+
+	for ; n > 0; n -= n & -n {
+		res = f.combine(res, f.nodes[n])
+	}
+
+	return res
+}
@@ -0,0 +1,67 @@
+// Segment tree over a fixed-length array with a user-supplied associative
+// combine function. Point updates and range queries both take O(log n). The
+// combine function doesn't need to be commutative.
+
+package segtree
+
+type SegmentTree[T any] struct {
+	n        int
+	nodes    []T
+	combine  func(a, b T) T
+	identity T
+}
+
+// Builds a segment tree over vals. identity must satisfy
+// combine(identity, x) == combine(x, identity) == x.
+func New[T any](vals []T, combine func(a, b T) T, identity T) *SegmentTree[T] {
+	n := len(vals)
+	t := &SegmentTree[T]{n, make([]T, 2*n), combine, identity}
+
+	copy(t.nodes[n:], vals)
+
+	for i := n - 1; i > 0; i-- {
+		t.nodes[i] = combine(t.nodes[2*i], t.nodes[2*i+1])
+	}
+
+	return t
+}
+
+func (t *SegmentTree[T]) Len() int {
+	return t.n
+}
+
+// Returns the element at index i.
+func (t *SegmentTree[T]) Get(i int) T {
+	return t.nodes[i+t.n]
+}
+
+// Sets the element at index i to val.
+func (t *SegmentTree[T]) Set(i int, val T) {
+	i += t.n
+	t.nodes[i] = val
+
+	for i /= 2; i > 0; i /= 2 {
+		t.nodes[i] = t.combine(t.nodes[2*i], t.nodes[2*i+1])
+	}
+}
+
+// Returns the combination of the elements in [lo, hi), in index order.
+// Returns the identity for an empty range.
+func (t *SegmentTree[T]) Query(lo, hi int) T {
+	left, right := t.identity, t.identity
+
+	// Left and right results are kept apart so the order is preserved
+	for lo, hi = lo+t.n, hi+t.n; lo < hi; lo, hi = lo/2, hi/2 {
+		if lo%2 == 1 {
+			left = t.combine(left, t.nodes[lo])
+			lo++
+		}
+
+		if hi%2 == 1 {
+			hi--
+			right = t.combine(t.nodes[hi], right)
+		}
+	}
+
+	return t.combine(left, right)
+}
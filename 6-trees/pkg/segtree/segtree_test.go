@@ -0,0 +1,152 @@
+package segtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func sum(a, b int) int {
+	return a + b
+}
+
+func minInt(a, b int) int {
+	return min(a, b)
+}
+
+func maxInt(a, b int) int {
+	return max(a, b)
+}
+
+// Brute force fold over vals[lo:hi].
+func fold[T any](vals []T, lo, hi int, combine func(a, b T) T, identity T) T {
+	res := identity
+
+	for _, v := range vals[lo:hi] {
+		res = combine(res, v)
+	}
+
+	return res
+}
+
+func TestSegmentTreeSum(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 2, 7, 64, 100} {
+		vals := make([]int, n)
+
+		for i := range vals {
+			vals[i] = rng.Intn(1000) - 500
+		}
+
+		tree := New(vals, sum, 0)
+
+		for i := 0; i < 500; i++ {
+			if n > 0 && rng.Intn(2) == 0 {
+				idx, v := rng.Intn(n), rng.Intn(1000)-500
+				vals[idx] = v
+				tree.Set(idx, v)
+			}
+
+			lo := rng.Intn(n + 1)
+			hi := lo + rng.Intn(n-lo+1)
+
+			if got, want := tree.Query(lo, hi), fold(vals, lo, hi, sum, 0); got != want {
+				t.Fatalf("n = %d, Query(%d, %d): got %d, want %d", n, lo, hi, got, want)
+			}
+		}
+
+		for i, v := range vals {
+			if tree.Get(i) != v {
+				t.Fatalf("Get(%d): got %d, want %d", i, tree.Get(i), v)
+			}
+		}
+	}
+}
+
+func TestSegmentTreeOrder(t *testing.T) {
+	// Concatenation isn't commutative, so this catches misordered combines
+	concat := func(a, b string) string { return a + b }
+	rng := rand.New(rand.NewSource(1))
+	vals := make([]string, 37)
+
+	for i := range vals {
+		vals[i] = string(rune('a' + rng.Intn(26)))
+	}
+
+	tree := New(vals, concat, "")
+
+	for i := 0; i < 500; i++ {
+		idx := rng.Intn(len(vals))
+		vals[idx] = string(rune('A' + rng.Intn(26)))
+		tree.Set(idx, vals[idx])
+
+		lo := rng.Intn(len(vals) + 1)
+		hi := lo + rng.Intn(len(vals)-lo+1)
+
+		if got, want := tree.Query(lo, hi), fold(vals, lo, hi, concat, ""); got != want {
+			t.Fatalf("Query(%d, %d): got %q, want %q", lo, hi, got, want)
+		}
+	}
+}
+
+func TestSegmentTreeMin(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	vals := make([]int, 50)
+
+	for i := range vals {
+		vals[i] = rng.Int()
+	}
+
+	tree := New(vals, minInt, int(^uint(0)>>1))
+
+	for lo := 0; lo < len(vals); lo++ {
+		for hi := lo + 1; hi <= len(vals); hi++ {
+			if got, want := tree.Query(lo, hi), fold(vals, lo, hi, minInt, int(^uint(0)>>1)); got != want {
+				t.Fatalf("Query(%d, %d): got %d, want %d", lo, hi, got, want)
+			}
+		}
+	}
+}
+
+func TestFenwick(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 5, 64, 100} {
+		vals := make([]int, n)
+
+		for i := range vals {
+			vals[i] = rng.Intn(100)
+		}
+
+		f := NewFenwickFrom(vals, sum, 0)
+		g := NewFenwick(n, maxInt, 0)
+
+		for i, v := range vals {
+			g.Add(i, v)
+		}
+
+		for i := 0; i < 500; i++ {
+			if n > 0 {
+				idx, v := rng.Intn(n), rng.Intn(100)
+				vals[idx] += v
+				f.Add(idx, v)
+				g.Add(idx, vals[idx])
+			}
+
+			p := rng.Intn(n + 1)
+
+			if got, want := f.Prefix(p), fold(vals, 0, p, sum, 0); got != want {
+				t.Fatalf("n = %d, sum Prefix(%d): got %d, want %d", n, p, got, want)
+			}
+
+			// Elements only grow, so max over the added values is the prefix max
+			if got, want := g.Prefix(p), fold(vals, 0, p, maxInt, 0); got != want {
+				t.Fatalf("n = %d, max Prefix(%d): got %d, want %d", n, p, got, want)
+			}
+		}
+
+		if f.Len() != n {
+			t.Fatalf("Len: got %d, want %d", f.Len(), n)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Skip list: a sorted linked list with extra express lanes, where each node is
+// promoted to the next level with probability 1/4. Each link also stores how
+// many nodes it skips, which makes rank queries O(log n) as well.
+
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+)
+
+const MaxLevel = 32
+
+type node[K cmp.Ordered, V any] struct {
+	Key  K
+	Val  V
+	next []*node[K, V]
+	// Number of level 0 steps to next at each level, or to the end of the
+	// list if next is nil
+	span []int
+}
+
+type SkipList[K cmp.Ordered, V any] struct {
+	head   *node[K, V]
+	level  int
+	length int
+}
+
+func New[K cmp.Ordered, V any]() *SkipList[K, V] {
+	head := &node[K, V]{
+		next: make([]*node[K, V], MaxLevel),
+		span: make([]int, MaxLevel),
+	}
+
+	return &SkipList[K, V]{head: head, level: 1}
+}
+
+func (l *SkipList[K, V]) Len() int {
+	return l.length
+}
+
+func randomLevel() int {
+	level := 1
+
+	for level < MaxLevel && rand.Intn(4) == 0 {
+		level++
+	}
+
+	return level
+}
+
+// Returns the last node before key at every level, along with the rank of
+// each of those nodes (the head having rank 0).
+func (l *SkipList[K, V]) findPrev(key K) ([MaxLevel]*node[K, V], [MaxLevel]int) {
+	var update [MaxLevel]*node[K, V]
+	var rank [MaxLevel]int
+	x := l.head
+
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+
+		for x.next[i] != nil && x.next[i].Key < key {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+
+		update[i] = x
+	}
+
+	return update, rank
+}
+
+func (l *SkipList[K, V]) Lookup(key K) (V, bool) {
+	x := l.head
+
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].Key < key {
+			x = x.next[i]
+		}
+	}
+
+	if x = x.next[0]; x != nil && x.Key == key {
+		return x.Val, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Returns true if the key was added, false if it was updated.
+func (l *SkipList[K, V]) Insert(key K, val V) bool {
+	update, rank := l.findPrev(key)
+
+	if x := update[0].next[0]; x != nil && x.Key == key {
+		x.Val = val
+		return false
+	}
+
+	level := randomLevel()
+
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = l.head
+			l.head.span[i] = l.length
+		}
+
+		l.level = level
+	}
+
+	x := &node[K, V]{
+		Key:  key,
+		Val:  val,
+		next: make([]*node[K, V], level),
+		span: make([]int, level),
+	}
+
+	for i := 0; i < level; i++ {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+
+		// rank[0]-rank[i] is the distance from update[i] to x's predecessor
+		x.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+
+	for i := level; i < l.level; i++ {
+		update[i].span[i]++
+	}
+
+	l.length++
+	return true
+}
+
+func (l *SkipList[K, V]) Delete(key K) (V, bool) {
+	update, _ := l.findPrev(key)
+	x := update[0].next[0]
+
+	if x == nil || x.Key != key {
+		var zero V
+		return zero, false
+	}
+
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+
+	l.length--
+	return x.Val, true
+}
+
+// Returns the number of keys less than key.
+func (l *SkipList[K, V]) Rank(key K) int {
+	_, rank := l.findPrev(key)
+	return rank[0]
+}
+
+// Returns the k-th smallest key and its value, counting from 0.
+func (l *SkipList[K, V]) Select(k int) (K, V, bool) {
+	if k < 0 || k >= l.length {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+
+	x := l.head
+	traversed := 0
+
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && traversed+x.span[i] <= k+1 {
+			traversed += x.span[i]
+			x = x.next[i]
+		}
+
+		if traversed == k+1 {
+			break
+		}
+	}
+
+	return x.Key, x.Val, true
+}
+
+// Iterates over all keys in order.
+func (l *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := l.head.next[0]; x != nil; x = x.next[0] {
+			if !yield(x.Key, x.Val) {
+				return
+			}
+		}
+	}
+}
+
+// Iterates over the keys in [lo, hi] in order.
+func (l *SkipList[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		update, _ := l.findPrev(lo)
+
+		for x := update[0].next[0]; x != nil && x.Key <= hi; x = x.next[0] {
+			if !yield(x.Key, x.Val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package skiplist
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Checks the list against a map holding the same keys.
+func check(t *testing.T, l *SkipList[int, int], want map[int]int) {
+	t.Helper()
+
+	keys := slices.Sorted(maps.Keys(want))
+
+	if l.Len() != len(keys) {
+		t.Fatalf("length: got %d, want %d", l.Len(), len(keys))
+	}
+
+	got := []int{}
+
+	for k, v := range l.All() {
+		if v != want[k] {
+			t.Fatalf("key %d: got value %d, want %d", k, v, want[k])
+		}
+
+		got = append(got, k)
+	}
+
+	if !slices.Equal(got, keys) {
+		t.Fatalf("keys: got %v, want %v", got, keys)
+	}
+
+	for i, k := range keys {
+		if r := l.Rank(k); r != i {
+			t.Fatalf("Rank(%d): got %d, want %d", k, r, i)
+		}
+
+		if sk, sv, ok := l.Select(i); !ok || sk != k || sv != want[k] {
+			t.Fatalf("Select(%d): got %d, %d, %t, want %d", i, sk, sv, ok, k)
+		}
+	}
+
+	if _, _, ok := l.Select(len(keys)); ok {
+		t.Fatal("Select past the end succeeded")
+	}
+}
+
+func TestAgainstMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	l := New[int, int]()
+	want := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(200)
+
+		switch rng.Intn(3) {
+		case 0, 1:
+			_, exists := want[key]
+
+			if added := l.Insert(key, i); added == exists {
+				t.Fatalf("Insert(%d): got added %t with key present %t", key, added, exists)
+			}
+
+			want[key] = i
+		case 2:
+			v, ok := l.Delete(key)
+			wv, wok := want[key]
+
+			if ok != wok || v != wv {
+				t.Fatalf("Delete(%d): got %d, %t, want %d, %t", key, v, ok, wv, wok)
+			}
+
+			delete(want, key)
+		}
+
+		wv, wok := want[key]
+
+		if v, ok := l.Lookup(key); v != wv || ok != wok {
+			t.Fatalf("Lookup(%d): got %d, %t, want %d, %t", key, v, ok, wv, wok)
+		}
+
+		if i%100 == 0 {
+			check(t, l, want)
+		}
+	}
+
+	check(t, l, want)
+}
+
+func TestRange(t *testing.T) {
+	l := New[int, int]()
+
+	for i := 0; i < 100; i += 2 {
+		l.Insert(i, i*i)
+	}
+
+	cases := []struct {
+		lo, hi int
+		want   []int
+	}{
+		{10, 16, []int{10, 12, 14, 16}},
+		{9, 15, []int{10, 12, 14}},
+		{-5, 3, []int{0, 2}},
+		{95, 200, []int{96, 98}},
+		{5, 5, []int{}},
+		{20, 10, []int{}},
+	}
+
+	for _, c := range cases {
+		got := []int{}
+
+		for k, v := range l.Range(c.lo, c.hi) {
+			if v != k*k {
+				t.Fatalf("key %d: got value %d", k, v)
+			}
+
+			got = append(got, k)
+		}
+
+		if !slices.Equal(got, c.want) {
+			t.Errorf("Range(%d, %d): got %v, want %v", c.lo, c.hi, got, c.want)
+		}
+	}
+
+	if l.Rank(-1) != 0 || l.Rank(11) != 6 || l.Rank(1000) != 50 {
+		t.Error("Rank of absent keys is wrong")
+	}
+}
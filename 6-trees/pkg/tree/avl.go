@@ -0,0 +1,129 @@
+package tree
+
+import (
+	"cmp"
+)
+
+// Binary search tree that keeps the heights of the two subtrees of every node
+// within one of each other, rotating on the way back up after an insert.
+type AVLTreeNode[K cmp.Ordered, V any] struct {
+	Key    K
+	Val    V
+	Left   *AVLTreeNode[K, V]
+	Right  *AVLTreeNode[K, V]
+	height int
+}
+
+type AVLTree[K cmp.Ordered, V any] struct {
+	Root *AVLTreeNode[K, V]
+}
+
+func NewAVLTree[K cmp.Ordered, V any]() *AVLTree[K, V] {
+	return &AVLTree[K, V]{nil}
+}
+
+func (t *AVLTree[K, V]) Lookup(key K) (V, bool) {
+	return t.Root.lookup(key)
+}
+
+func (node *AVLTreeNode[K, V]) lookup(key K) (V, bool) {
+	for node != nil {
+		if node.Key == key {
+			return node.Val, true
+		}
+
+		if key < node.Key {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (t *AVLTree[K, V]) Add(key K, val V) {
+	t.Root = t.Root.add(key, val)
+}
+
+// Returns the root of the subtree after adding the key.
+func (node *AVLTreeNode[K, V]) add(key K, val V) *AVLTreeNode[K, V] {
+	if node == nil {
+		return &AVLTreeNode[K, V]{Key: key, Val: val, height: 1}
+	}
+
+	if node.Key == key {
+		node.Val = val
+		return node
+	}
+
+	if key < node.Key {
+		node.Left = node.Left.add(key, val)
+	} else {
+		node.Right = node.Right.add(key, val)
+	}
+
+	return node.rebalance()
+}
+
+func (node *AVLTreeNode[K, V]) Height() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.height
+}
+
+func (node *AVLTreeNode[K, V]) update() {
+	node.height = max(node.Left.Height(), node.Right.Height()) + 1
+}
+
+// Left height minus right height.
+func (node *AVLTreeNode[K, V]) balance() int {
+	return node.Left.Height() - node.Right.Height()
+}
+
+func (node *AVLTreeNode[K, V]) rotateLeft() *AVLTreeNode[K, V] {
+	right := node.Right
+	node.Right = right.Left
+	right.Left = node
+
+	node.update()
+	right.update()
+
+	return right
+}
+
+func (node *AVLTreeNode[K, V]) rotateRight() *AVLTreeNode[K, V] {
+	left := node.Left
+	node.Left = left.Right
+	left.Right = node
+
+	node.update()
+	left.update()
+
+	return left
+}
+
+func (node *AVLTreeNode[K, V]) rebalance() *AVLTreeNode[K, V] {
+	node.update()
+
+	switch b := node.balance(); {
+	case b > 1:
+		// Left-right case becomes left-left
+		if node.Left.balance() < 0 {
+			node.Left = node.Left.rotateLeft()
+		}
+
+		return node.rotateRight()
+	case b < -1:
+		if node.Right.balance() > 0 {
+			node.Right = node.Right.rotateRight()
+		}
+
+		return node.rotateLeft()
+	}
+
+	return node
+}
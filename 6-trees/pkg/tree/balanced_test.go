@@ -0,0 +1,136 @@
+package tree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns the height of the subtree, failing if it isn't ordered within
+// (lo, hi) or is out of balance.
+func checkAVL(t *testing.T, node *AVLTreeNode[int, int], lo, hi int) int {
+	t.Helper()
+
+	if node == nil {
+		return 0
+	}
+
+	if node.Key <= lo || node.Key >= hi {
+		t.Fatalf("key %d outside (%d, %d)", node.Key, lo, hi)
+	}
+
+	left := checkAVL(t, node.Left, lo, node.Key)
+	right := checkAVL(t, node.Right, node.Key, hi)
+
+	if left-right > 1 || right-left > 1 {
+		t.Fatalf("node %d: subtree heights %d and %d", node.Key, left, right)
+	}
+
+	if node.height != max(left, right)+1 {
+		t.Fatalf("node %d: stored height %d, actual %d", node.Key, node.height, max(left, right)+1)
+	}
+
+	return node.height
+}
+
+// Returns the number of black links below the node, failing if the subtree
+// isn't ordered within (lo, hi) or breaks a red-black rule.
+func checkRB(t *testing.T, node *RedBlackTreeNode[int, int], lo, hi int) int {
+	t.Helper()
+
+	if node == nil {
+		return 0
+	}
+
+	if node.Key <= lo || node.Key >= hi {
+		t.Fatalf("key %d outside (%d, %d)", node.Key, lo, hi)
+	}
+
+	if node.Right.isRed() {
+		t.Fatalf("node %d: red link leans right", node.Key)
+	}
+
+	if node.red && node.Left.isRed() {
+		t.Fatalf("node %d: two red links in a row", node.Key)
+	}
+
+	left := checkRB(t, node.Left, lo, node.Key)
+	right := checkRB(t, node.Right, node.Key, hi)
+
+	if left != right {
+		t.Fatalf("node %d: black heights %d and %d", node.Key, left, right)
+	}
+
+	if !node.red {
+		left++
+	}
+
+	return left
+}
+
+// Adds keys in the given order, checking the invariants and comparing lookups
+// with a map.
+func testBalanced(t *testing.T, keys []int) {
+	avl := NewAVLTree[int, int]()
+	rb := NewRedBlackTree[int, int]()
+	want := map[int]int{}
+
+	for i, key := range keys {
+		avl.Add(key, i)
+		rb.Add(key, i)
+		want[key] = i
+
+		if i%64 == 0 || i == len(keys)-1 {
+			h := checkAVL(t, avl.Root, -1<<62, 1<<62)
+
+			if rb.Root.isRed() {
+				t.Fatal("red-black root is red")
+			}
+
+			checkRB(t, rb.Root, -1<<62, 1<<62)
+
+			// A height balanced tree is at most 1.44 log2(n) high
+			if n := len(want); float64(h) > 1.45*math.Log2(float64(n+2)) {
+				t.Fatalf("AVL tree of %d keys has height %d", n, h)
+			}
+		}
+	}
+
+	for key := -1; key <= len(keys)+1; key++ {
+		wv, wok := want[key]
+
+		for name, tree := range map[string]Tree[int, int]{"AVL": avl, "red-black": rb} {
+			if v, ok := tree.Lookup(key); v != wv || ok != wok {
+				t.Fatalf("%s Lookup(%d): got %d, %t, want %d, %t", name, key, v, ok, wv, wok)
+			}
+		}
+	}
+}
+
+func TestBalancedOrdered(t *testing.T) {
+	keys := make([]int, 2000)
+
+	for i := range keys {
+		keys[i] = i
+	}
+
+	testBalanced(t, keys)
+
+	for i := range keys {
+		keys[i] = len(keys) - i
+	}
+
+	testBalanced(t, keys)
+}
+
+func TestBalancedRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	keys := make([]int, 2000)
+
+	for i := range keys {
+		// Small range so that some keys are updated
+		keys[i] = rng.Intn(1500)
+	}
+
+	testBalanced(t, keys)
+}
@@ -9,6 +9,8 @@ type BinaryTreeNode[K cmp.Ordered, V any] struct {
 	Val   V
 	Left  *BinaryTreeNode[K, V]
 	Right *BinaryTreeNode[K, V]
+	// Number of nodes in the subtree rooted at this node
+	size int
 }
 
 type BinaryTree[K cmp.Ordered, V any] struct {
@@ -16,11 +18,11 @@ type BinaryTree[K cmp.Ordered, V any] struct {
 }
 
 func NewBinaryTreeNode[K cmp.Ordered, V any](key K, val V) *BinaryTreeNode[K, V] {
-	return &BinaryTreeNode[K, V]{key, val, nil, nil}
+	return &BinaryTreeNode[K, V]{key, val, nil, nil, 1}
 }
 
 func NewBinaryTree[K cmp.Ordered, V any]() *BinaryTree[K, V] {
-	return &BinaryTree[K, V]{nil}
+	return &BinaryTree[K, V]{}
 }
 
 func (t *BinaryTree[K, V]) Lookup(key K) (V, bool) {
@@ -53,6 +55,18 @@ func (t *BinaryTree[K, V]) Add(key K, val V) {
 	t.Root.add(key, val)
 }
 
+func (t *BinaryTree[K, V]) Len() int {
+	return t.Root.Size()
+}
+
+func (node *BinaryTreeNode[K, V]) Size() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.size
+}
+
 // Returns true if the key was added, false if it was updated.
 func (node *BinaryTreeNode[K, V]) add(key K, val V) bool {
 	if node.Key == key {
@@ -60,21 +74,85 @@ func (node *BinaryTreeNode[K, V]) add(key K, val V) bool {
 		return false
 	}
 
+	added := true
+
 	if key < node.Key {
 		if node.Left == nil {
 			node.Left = NewBinaryTreeNode(key, val)
-			return true
+		} else {
+			added = node.Left.add(key, val)
+		}
+	} else {
+		if node.Right == nil {
+			node.Right = NewBinaryTreeNode(key, val)
+		} else {
+			added = node.Right.add(key, val)
 		}
+	}
 
-		return node.Left.add(key, val)
+	if added {
+		node.size++
+	}
+
+	return added
+}
+
+// Removes the key and returns its value, or false if it isn't in the tree.
+func (t *BinaryTree[K, V]) Delete(key K) (V, bool) {
+	root, val, ok := t.Root.delete(key)
+	t.Root = root
+
+	return val, ok
+}
+
+// Returns the root of the subtree after deleting the key.
+func (node *BinaryTreeNode[K, V]) delete(key K) (*BinaryTreeNode[K, V], V, bool) {
+	if node == nil {
+		var zero V
+		return nil, zero, false
+	}
+
+	var val V
+	var ok bool
+
+	if key != node.Key {
+		if key < node.Key {
+			node.Left, val, ok = node.Left.delete(key)
+		} else {
+			node.Right, val, ok = node.Right.delete(key)
+		}
+
+		if ok {
+			node.size--
+		}
+
+		return node, val, ok
+	}
+
+	val = node.Val
+
+	// Leaves and nodes with one child are replaced by their child
+	if node.Left == nil {
+		return node.Right, val, true
 	}
 
 	if node.Right == nil {
-		node.Right = NewBinaryTreeNode(key, val)
-		return true
+		return node.Left, val, true
 	}
 
-	return node.Right.add(key, val)
+	// Otherwise take over the successor, the leftmost node of the right
+	// subtree, which has no left child
+	succ := node.Right
+
+	for succ.Left != nil {
+		succ = succ.Left
+	}
+
+	node.Key, node.Val = succ.Key, succ.Val
+	node.Right, _, _ = node.Right.delete(succ.Key)
+	node.size--
+
+	return node, val, true
 }
 
 func (t *BinaryTree[K, V]) DepthFirstList() []V {
@@ -89,6 +167,10 @@ func (node *BinaryTreeNode[K, V]) depthFirstList() []V {
 	return append(append(node.Left.depthFirstList(), node.Val), node.Right.depthFirstList()...)
 }
 
+// Sends the nodes in order on a channel from a separate goroutine.
+//
+// Deprecated: the goroutine leaks if the consumer stops early, and every node
+// costs a channel handoff. Use InOrder or a Cursor instead.
 func (t *BinaryTree[K, V]) DepthFirstIterator() <-chan *BinaryTreeNode[K, V] {
 	ch := make(chan *BinaryTreeNode[K, V])
 
@@ -0,0 +1,129 @@
+package tree
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Checks that the tree holds exactly the keys and values of want, in order.
+func checkBinary(t *testing.T, tree *BinaryTree[int, int], want map[int]int) {
+	t.Helper()
+
+	if tree.Len() != len(want) {
+		t.Fatalf("Len: got %d, want %d", tree.Len(), len(want))
+	}
+
+	keys := []int{}
+
+	for key, val := range tree.InOrder() {
+		if v, ok := want[key]; !ok || v != val {
+			t.Fatalf("key %d: got value %d, want %d, %t", key, val, v, ok)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if !slices.Equal(keys, slices.Sorted(maps.Keys(want))) {
+		t.Fatalf("keys out of order or missing: %v", keys)
+	}
+}
+
+func TestDeleteCases(t *testing.T) {
+	//        5
+	//     3     8
+	//    2 4   7
+	//         6
+	build := func() (*BinaryTree[int, int], map[int]int) {
+		tree := NewBinaryTree[int, int]()
+		want := map[int]int{}
+
+		for _, key := range []int{5, 3, 8, 2, 4, 7, 6} {
+			tree.Add(key, key*10)
+			want[key] = key * 10
+		}
+
+		return tree, want
+	}
+
+	cases := []struct {
+		name string
+		key  int
+	}{
+		{"leaf", 2},
+		{"one child", 7},
+		{"one child at root of subtree", 8},
+		{"two children", 3},
+		{"two children at root", 5},
+	}
+
+	for _, c := range cases {
+		tree, want := build()
+
+		if v, ok := tree.Delete(c.key); !ok || v != c.key*10 {
+			t.Errorf("%s: Delete(%d) got %d, %t", c.name, c.key, v, ok)
+		}
+
+		delete(want, c.key)
+		checkBinary(t, tree, want)
+
+		if _, ok := tree.Lookup(c.key); ok {
+			t.Errorf("%s: %d still found after delete", c.name, c.key)
+		}
+	}
+
+	tree, want := build()
+
+	if _, ok := tree.Delete(1); ok {
+		t.Error("deleted a key that isn't in the tree")
+	}
+
+	checkBinary(t, tree, want)
+
+	for key := range want {
+		tree.Delete(key)
+	}
+
+	checkBinary(t, tree, map[int]int{})
+
+	if tree.Root != nil {
+		t.Error("empty tree has a root")
+	}
+}
+
+func TestAddDeleteAgainstMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewBinaryTree[int, int]()
+	want := map[int]int{}
+
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(500)
+
+		if rng.Intn(2) == 0 {
+			tree.Add(key, i)
+			want[key] = i
+		} else {
+			v, ok := tree.Delete(key)
+			wv, wok := want[key]
+
+			if v != wv || ok != wok {
+				t.Fatalf("Delete(%d): got %d, %t, want %d, %t", key, v, ok, wv, wok)
+			}
+
+			delete(want, key)
+		}
+
+		wv, wok := want[key]
+
+		if v, ok := tree.Lookup(key); v != wv || ok != wok {
+			t.Fatalf("Lookup(%d): got %d, %t, want %d, %t", key, v, ok, wv, wok)
+		}
+
+		if i%500 == 0 {
+			checkBinary(t, tree, want)
+		}
+	}
+
+	checkBinary(t, tree, want)
+}
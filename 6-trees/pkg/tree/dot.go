@@ -0,0 +1,64 @@
+package tree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Escapes only " and \, which would otherwise end a DOT string early or start
+// an escape. Everything else, tabs and non-ASCII included, is passed through
+// for Graphviz to render as is.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func quoteDOT(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// Writes the tree in Graphviz DOT format, labelling nodes with their keys.
+// Missing children are drawn as invisible nodes so that a lone child still
+// leans the right way. Render with e.g. `dot -Tpng tree.dot -o tree.png`.
+func (t *BinaryTree[K, V]) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph tree {")
+	fmt.Fprintln(bw, "\tnode [shape=circle];")
+
+	id := 0
+	t.Root.writeDOT(bw, &id)
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// Writes the subtree and returns the ID of its root.
+func (node *BinaryTreeNode[K, V]) writeDOT(w io.Writer, id *int) int {
+	self := *id
+	*id++
+
+	if node == nil {
+		fmt.Fprintf(w, "\tn%d [label=\"\", style=invis, width=0.1];\n", self)
+		return self
+	}
+
+	fmt.Fprintf(w, "\tn%d [label=%s];\n", self, quoteDOT(fmt.Sprint(node.Key)))
+
+	if node.Left == nil && node.Right == nil {
+		return self
+	}
+
+	left := node.Left.writeDOT(w, id)
+	right := node.Right.writeDOT(w, id)
+	writeEdge(w, self, left, node.Left == nil)
+	writeEdge(w, self, right, node.Right == nil)
+
+	return self
+}
+
+func writeEdge(w io.Writer, from, to int, invisible bool) {
+	if invisible {
+		fmt.Fprintf(w, "\tn%d -> n%d [style=invis];\n", from, to)
+	} else {
+		fmt.Fprintf(w, "\tn%d -> n%d;\n", from, to)
+	}
+}
@@ -0,0 +1,232 @@
+package tree
+
+import (
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrCorrupt = errors.New("corrupt tree encoding")
+
+// Converts keys or values to and from bytes for EncodeBinary and
+// DecodeBinary.
+type Codec[T any] struct {
+	Append func(buf []byte, v T) []byte
+	// Decodes a value from the start of buf and returns the number of bytes
+	// it took
+	Read func(buf []byte) (T, int, error)
+}
+
+// Encodes ints as zig-zag varints.
+var IntCodec = Codec[int]{
+	Append: func(buf []byte, v int) []byte {
+		return binary.AppendVarint(buf, int64(v))
+	},
+	Read: func(buf []byte) (int, int, error) {
+		v, n := binary.Varint(buf)
+
+		if n <= 0 {
+			return 0, 0, ErrCorrupt
+		}
+
+		return int(v), n, nil
+	},
+}
+
+// Encodes strings with a varint length prefix.
+var StringCodec = Codec[string]{
+	Append: func(buf []byte, v string) []byte {
+		return append(binary.AppendUvarint(buf, uint64(len(v))), v...)
+	},
+	Read: func(buf []byte) (string, int, error) {
+		length, n := binary.Uvarint(buf)
+
+		if n <= 0 || length > uint64(len(buf)-n) {
+			return "", 0, ErrCorrupt
+		}
+
+		return string(buf[n : n+int(length)]), n + int(length), nil
+	},
+}
+
+// Encodes the tree in pre-order, with a marker byte for every node and every
+// empty child, so that decoding gives back the same shape.
+func (t *BinaryTree[K, V]) EncodeBinary(keys Codec[K], vals Codec[V]) []byte {
+	return t.Root.encodeBinary(nil, keys, vals)
+}
+
+func (node *BinaryTreeNode[K, V]) encodeBinary(buf []byte, keys Codec[K], vals Codec[V]) []byte {
+	if node == nil {
+		return append(buf, 0)
+	}
+
+	buf = append(buf, 1)
+	buf = keys.Append(buf, node.Key)
+	buf = vals.Append(buf, node.Val)
+	buf = node.Left.encodeBinary(buf, keys, vals)
+
+	return node.Right.encodeBinary(buf, keys, vals)
+}
+
+// Decodes a tree written by EncodeBinary. Fails if the data is cut short, has
+// bytes left over or doesn't describe a binary search tree.
+func DecodeBinary[K cmp.Ordered, V any](buf []byte, keys Codec[K], vals Codec[V]) (*BinaryTree[K, V], error) {
+	d := decoder[K, V]{buf: buf, keys: keys, vals: vals}
+	root, err := d.node(nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d.buf) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrCorrupt, len(d.buf))
+	}
+
+	return &BinaryTree[K, V]{root}, nil
+}
+
+type decoder[K cmp.Ordered, V any] struct {
+	buf  []byte
+	keys Codec[K]
+	vals Codec[V]
+}
+
+// Decodes a subtree whose keys must be within (lo, hi), where nil means
+// unbounded.
+func (d *decoder[K, V]) node(lo, hi *K) (*BinaryTreeNode[K, V], error) {
+	if len(d.buf) == 0 {
+		return nil, ErrCorrupt
+	}
+
+	marker := d.buf[0]
+	d.buf = d.buf[1:]
+
+	if marker == 0 {
+		return nil, nil
+	}
+
+	if marker != 1 {
+		return nil, fmt.Errorf("%w: bad marker %d", ErrCorrupt, marker)
+	}
+
+	key, n, err := d.keys.Read(d.buf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.buf = d.buf[n:]
+	val, n, err := d.vals.Read(d.buf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.buf = d.buf[n:]
+
+	if err := checkBounds(key, lo, hi); err != nil {
+		return nil, err
+	}
+
+	node := NewBinaryTreeNode(key, val)
+
+	if node.Left, err = d.node(lo, &node.Key); err != nil {
+		return nil, err
+	}
+
+	if node.Right, err = d.node(&node.Key, hi); err != nil {
+		return nil, err
+	}
+
+	node.size += node.Left.Size() + node.Right.Size()
+	return node, nil
+}
+
+func checkBounds[K cmp.Ordered](key K, lo, hi *K) error {
+	if (lo != nil && key <= *lo) || (hi != nil && key >= *hi) {
+		return fmt.Errorf("%w: key %v out of order", ErrCorrupt, key)
+	}
+
+	return nil
+}
+
+// Encodes the tree as nested objects with Key, Val, Left and Right fields, or
+// null if it is empty.
+func (t *BinaryTree[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Root)
+}
+
+// Decodes a tree written by MarshalJSON, keeping its shape. Fails if it isn't
+// a binary search tree.
+func (t *BinaryTree[K, V]) UnmarshalJSON(data []byte) error {
+	var root *BinaryTreeNode[K, V]
+
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+
+	if _, err := root.fixSizes(nil, nil); err != nil {
+		return err
+	}
+
+	t.Root = root
+	return nil
+}
+
+// Checks the order of the subtree and sets the sizes that JSON doesn't carry.
+func (node *BinaryTreeNode[K, V]) fixSizes(lo, hi *K) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+
+	if err := checkBounds(node.Key, lo, hi); err != nil {
+		return 0, err
+	}
+
+	left, err := node.Left.fixSizes(lo, &node.Key)
+
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := node.Right.fixSizes(&node.Key, hi)
+
+	if err != nil {
+		return 0, err
+	}
+
+	node.size = left + right + 1
+	return node.size, nil
+}
+
+// Builds a tree of minimum height from keys in increasing order, with vals[i]
+// as the value of keys[i].
+func NewBalancedBinaryTree[K cmp.Ordered, V any](keys []K, vals []V) (*BinaryTree[K, V], error) {
+	if len(keys) != len(vals) {
+		return nil, errors.New("keys and values differ in length")
+	}
+
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			return nil, fmt.Errorf("keys not strictly increasing at index %d", i)
+		}
+	}
+
+	return &BinaryTree[K, V]{buildBalanced(keys, vals)}, nil
+}
+
+func buildBalanced[K cmp.Ordered, V any](keys []K, vals []V) *BinaryTreeNode[K, V] {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	mid := len(keys) / 2
+	node := NewBinaryTreeNode(keys[mid], vals[mid])
+	node.Left = buildBalanced(keys[:mid], vals[:mid])
+	node.Right = buildBalanced(keys[mid+1:], vals[mid+1:])
+	node.size = len(keys)
+
+	return node
+}
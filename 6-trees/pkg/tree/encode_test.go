@@ -0,0 +1,252 @@
+package tree
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Pre-order keys with -1 for empty children, which identifies the shape.
+func shape(node *BinaryTreeNode[int, string], keys []int) []int {
+	if node == nil {
+		return append(keys, -1)
+	}
+
+	keys = append(keys, node.Key)
+	keys = shape(node.Left, keys)
+
+	return shape(node.Right, keys)
+}
+
+func sampleTree() *BinaryTree[int, string] {
+	tree := NewBinaryTree[int, string]()
+
+	for _, key := range []int{50, 20, 80, 10, 30, 90, 25, 95} {
+		tree.Add(key, strings.Repeat("v", key%7))
+	}
+
+	return tree
+}
+
+// Checks that b has the same shape, values and sizes as a.
+func expectSame(t *testing.T, a, b *BinaryTree[int, string]) {
+	t.Helper()
+
+	if !slices.Equal(shape(a.Root, nil), shape(b.Root, nil)) {
+		t.Fatalf("shape: got %v, want %v", shape(b.Root, nil), shape(a.Root, nil))
+	}
+
+	for key, val := range a.InOrder() {
+		if got, _ := b.Lookup(key); got != val {
+			t.Fatalf("key %d: got %q, want %q", key, got, val)
+		}
+
+		if a.Rank(key) != b.Rank(key) {
+			t.Fatalf("key %d: rank differs, sizes weren't restored", key)
+		}
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, tree := range []*BinaryTree[int, string]{sampleTree(), NewBinaryTree[int, string]()} {
+		buf := tree.EncodeBinary(IntCodec, StringCodec)
+		decoded, err := DecodeBinary(buf, IntCodec, StringCodec)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectSame(t, tree, decoded)
+	}
+
+	tree := NewBinaryTree[string, int]()
+	tree.Add("b", -1)
+	tree.Add("a", 1<<40)
+
+	decoded, err := DecodeBinary(tree.EncodeBinary(StringCodec, IntCodec), StringCodec, IntCodec)
+
+	if err != nil || decoded.Len() != 2 || decoded.Root.Left.Val != 1<<40 {
+		t.Errorf("string keyed round trip failed: %v", err)
+	}
+}
+
+func TestBinaryCorrupt(t *testing.T) {
+	buf := sampleTree().EncodeBinary(IntCodec, StringCodec)
+
+	for n := 0; n < len(buf); n++ {
+		if _, err := DecodeBinary(buf[:n], IntCodec, StringCodec); !errors.Is(err, ErrCorrupt) {
+			t.Fatalf("truncated to %d bytes: got %v", n, err)
+		}
+	}
+
+	if _, err := DecodeBinary(append(buf, 0), IntCodec, StringCodec); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("trailing byte: got %v", err)
+	}
+
+	// A left child larger than its parent
+	bad := NewBinaryTree[int, string]()
+	bad.Root = NewBinaryTreeNode(5, "")
+	bad.Root.Left = NewBinaryTreeNode(7, "")
+
+	if _, err := DecodeBinary(bad.EncodeBinary(IntCodec, StringCodec), IntCodec, StringCodec); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("unordered tree: got %v", err)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tree := sampleTree()
+	data, err := json.Marshal(tree)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := NewBinaryTree[int, string]()
+
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	expectSame(t, tree, decoded)
+
+	empty := NewBinaryTree[int, string]()
+
+	if data, _ := json.Marshal(empty); string(data) != "null" {
+		t.Errorf("empty tree: got %s", data)
+	}
+
+	bad := `{"Key": 5, "Val": "", "Left": null, "Right": {"Key": 3, "Val": ""}}`
+
+	if err := json.Unmarshal([]byte(bad), decoded); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("unordered tree: got %v", err)
+	}
+}
+
+func TestBalanced(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 1000} {
+		keys := make([]int, n)
+		vals := make([]string, n)
+
+		for i := range keys {
+			keys[i] = i * 3
+		}
+
+		tree, err := NewBalancedBinaryTree(keys, vals)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := []int{}
+
+		for key := range tree.InOrder() {
+			got = append(got, key)
+		}
+
+		if !slices.Equal(got, keys) || tree.Len() != n {
+			t.Fatalf("n=%d: got keys %v", n, got)
+		}
+
+		// Minimum height is the number of bits in n
+		height := 0
+
+		for level := range tree.levels() {
+			height = max(height, level+1)
+		}
+
+		if want := bitLen(n); height != want {
+			t.Errorf("n=%d: got height %d, want %d", n, height, want)
+		}
+	}
+
+	if _, err := NewBalancedBinaryTree([]int{1, 1}, []string{"", ""}); err == nil {
+		t.Error("accepted duplicate keys")
+	}
+
+	if _, err := NewBalancedBinaryTree([]int{1}, []string{}); err == nil {
+		t.Error("accepted mismatched lengths")
+	}
+}
+
+func bitLen(n int) int {
+	bits := 0
+
+	for ; n > 0; n >>= 1 {
+		bits++
+	}
+
+	return bits
+}
+
+// Yields the depth of every node.
+func (t *BinaryTree[K, V]) levels() func(func(int) bool) {
+	return func(yield func(int) bool) {
+		var walk func(node *BinaryTreeNode[K, V], depth int) bool
+
+		walk = func(node *BinaryTreeNode[K, V], depth int) bool {
+			if node == nil {
+				return true
+			}
+
+			return yield(depth) && walk(node.Left, depth+1) && walk(node.Right, depth+1)
+		}
+
+		walk(t.Root, 0)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	tree := NewBinaryTree[int, string]()
+
+	for _, key := range []int{2, 1, 3, 4} {
+		tree.Add(key, "")
+	}
+
+	var sb strings.Builder
+
+	if err := tree.WriteDOT(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `digraph tree {
+	node [shape=circle];
+	n0 [label="2"];
+	n1 [label="1"];
+	n2 [label="3"];
+	n3 [label="", style=invis, width=0.1];
+	n4 [label="4"];
+	n2 -> n3 [style=invis];
+	n2 -> n4;
+	n0 -> n1;
+	n0 -> n2;
+}
+`
+
+	if sb.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", sb.String(), want)
+	}
+}
+
+func TestWriteDOTEscapes(t *testing.T) {
+	tree := NewBinaryTree[string, int]()
+	tree.Add(`say "hi"`, 0)
+	tree.Add(`C:\dir`, 0)
+	tree.Add("tab\there", 0)
+	tree.Add("café", 0)
+
+	var sb strings.Builder
+
+	if err := tree.WriteDOT(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	labels := []string{`[label="say \"hi\""];`, `[label="C:\\dir"];`, "[label=\"tab\there\"];", `[label="café"];`}
+
+	for _, label := range labels {
+		if !strings.Contains(sb.String(), label) {
+			t.Errorf("missing %s in\n%s", label, sb.String())
+		}
+	}
+}
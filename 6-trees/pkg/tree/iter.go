@@ -0,0 +1,188 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Iterates over the keys in order. The stack is the only allocation.
+func (t *BinaryTree[K, V]) InOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		stack := []*BinaryTreeNode[K, V]{}
+		node := t.Root
+
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if !yield(node.Key, node.Val) {
+				return
+			}
+
+			node = node.Right
+		}
+	}
+}
+
+// Iterates over each node before its subtrees, left first.
+func (t *BinaryTree[K, V]) PreOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.Root == nil {
+			return
+		}
+
+		stack := []*BinaryTreeNode[K, V]{t.Root}
+
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if !yield(node.Key, node.Val) {
+				return
+			}
+
+			// Right first so that the left subtree is popped first
+			if node.Right != nil {
+				stack = append(stack, node.Right)
+			}
+
+			if node.Left != nil {
+				stack = append(stack, node.Left)
+			}
+		}
+	}
+}
+
+// Iterates over each node after its subtrees, left first.
+func (t *BinaryTree[K, V]) PostOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		stack := []*BinaryTreeNode[K, V]{}
+		var last *BinaryTreeNode[K, V]
+		node := t.Root
+
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+
+			top := stack[len(stack)-1]
+
+			// Visit the right subtree first unless we just came back from it
+			if top.Right != nil && top.Right != last {
+				node = top.Right
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+
+			if !yield(top.Key, top.Val) {
+				return
+			}
+
+			last = top
+		}
+	}
+}
+
+// Iterates over the nodes one depth at a time, left to right.
+func (t *BinaryTree[K, V]) LevelOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.Root == nil {
+			return
+		}
+
+		queue := []*BinaryTreeNode[K, V]{t.Root}
+
+		for i := 0; i < len(queue); i++ {
+			node := queue[i]
+
+			if !yield(node.Key, node.Val) {
+				return
+			}
+
+			if node.Left != nil {
+				queue = append(queue, node.Left)
+			}
+
+			if node.Right != nil {
+				queue = append(queue, node.Right)
+			}
+		}
+	}
+}
+
+// In-order cursor that can be moved forward one key at a time and repositioned
+// with Seek. The tree must not be modified while a cursor is in use.
+type Cursor[K cmp.Ordered, V any] struct {
+	tree *BinaryTree[K, V]
+	// Nodes whose key and right subtree haven't been visited yet, with the
+	// current node on top
+	stack []*BinaryTreeNode[K, V]
+}
+
+// Returns a cursor at the smallest key.
+func (t *BinaryTree[K, V]) Cursor() *Cursor[K, V] {
+	c := &Cursor[K, V]{tree: t}
+	c.First()
+
+	return c
+}
+
+func (c *Cursor[K, V]) pushLeft(node *BinaryTreeNode[K, V]) {
+	for node != nil {
+		c.stack = append(c.stack, node)
+		node = node.Left
+	}
+}
+
+// Moves to the smallest key.
+func (c *Cursor[K, V]) First() {
+	c.stack = c.stack[:0]
+	c.pushLeft(c.tree.Root)
+}
+
+// Moves to the smallest key greater than or equal to key.
+func (c *Cursor[K, V]) Seek(key K) {
+	c.stack = c.stack[:0]
+	node := c.tree.Root
+
+	for node != nil {
+		if node.Key < key {
+			node = node.Right
+		} else {
+			c.stack = append(c.stack, node)
+
+			if node.Key == key {
+				return
+			}
+
+			node = node.Left
+		}
+	}
+}
+
+// Returns false once the cursor has moved past the largest key.
+func (c *Cursor[K, V]) Valid() bool {
+	return len(c.stack) > 0
+}
+
+func (c *Cursor[K, V]) Key() K {
+	return c.stack[len(c.stack)-1].Key
+}
+
+func (c *Cursor[K, V]) Val() V {
+	return c.stack[len(c.stack)-1].Val
+}
+
+// Moves to the next key.
+func (c *Cursor[K, V]) Next() {
+	node := c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+	c.pushLeft(node.Right)
+}
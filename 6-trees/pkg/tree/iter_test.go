@@ -0,0 +1,173 @@
+package tree
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// Recursive reference traversal, appending keys in pre-, in- or post-order.
+func walk(node *BinaryTreeNode[int, int], order int, keys []int) []int {
+	if node == nil {
+		return keys
+	}
+
+	if order == 0 {
+		keys = append(keys, node.Key)
+	}
+
+	keys = walk(node.Left, order, keys)
+
+	if order == 1 {
+		keys = append(keys, node.Key)
+	}
+
+	keys = walk(node.Right, order, keys)
+
+	if order == 2 {
+		keys = append(keys, node.Key)
+	}
+
+	return keys
+}
+
+func collect(seq iter.Seq2[int, int]) []int {
+	keys := []int{}
+
+	for key, val := range seq {
+		if val != key*10 {
+			panic("value doesn't match key")
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func randomTree(n int) *BinaryTree[int, int] {
+	rng := rand.New(rand.NewSource(int64(n)))
+	tree := NewBinaryTree[int, int]()
+
+	for i := 0; i < n; i++ {
+		key := rng.Intn(4 * n)
+		tree.Add(key, key*10)
+	}
+
+	return tree
+}
+
+func TestTraversals(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 500} {
+		tree := randomTree(n)
+
+		if got, want := collect(tree.PreOrder()), walk(tree.Root, 0, []int{}); !slices.Equal(got, want) {
+			t.Errorf("n=%d PreOrder: got %v, want %v", n, got, want)
+		}
+
+		if got, want := collect(tree.InOrder()), walk(tree.Root, 1, []int{}); !slices.Equal(got, want) {
+			t.Errorf("n=%d InOrder: got %v, want %v", n, got, want)
+		}
+
+		if got, want := collect(tree.PostOrder()), walk(tree.Root, 2, []int{}); !slices.Equal(got, want) {
+			t.Errorf("n=%d PostOrder: got %v, want %v", n, got, want)
+		}
+	}
+
+	//      4
+	//    2   6
+	//   1 3   7
+	tree := NewBinaryTree[int, int]()
+
+	for _, key := range []int{4, 2, 6, 1, 3, 7} {
+		tree.Add(key, key*10)
+	}
+
+	if got := collect(tree.LevelOrder()); !slices.Equal(got, []int{4, 2, 6, 1, 3, 7}) {
+		t.Errorf("LevelOrder: got %v", got)
+	}
+
+	if got := collect(NewBinaryTree[int, int]().LevelOrder()); len(got) != 0 {
+		t.Errorf("LevelOrder of empty tree: got %v", got)
+	}
+}
+
+func TestTraversalStop(t *testing.T) {
+	tree := randomTree(100)
+	iters := map[string]iter.Seq2[int, int]{
+		"InOrder":    tree.InOrder(),
+		"PreOrder":   tree.PreOrder(),
+		"PostOrder":  tree.PostOrder(),
+		"LevelOrder": tree.LevelOrder(),
+	}
+
+	for name, seq := range iters {
+		n := 0
+
+		for range seq {
+			n++
+
+			if n == 5 {
+				break
+			}
+		}
+
+		if n != 5 {
+			t.Errorf("%s: stopped after %d keys", name, n)
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	tree := randomTree(300)
+	keys := walk(tree.Root, 1, []int{})
+
+	c := tree.Cursor()
+	got := []int{}
+
+	for ; c.Valid(); c.Next() {
+		if c.Val() != c.Key()*10 {
+			t.Fatalf("key %d has value %d", c.Key(), c.Val())
+		}
+
+		got = append(got, c.Key())
+	}
+
+	if !slices.Equal(got, keys) {
+		t.Fatalf("cursor: got %v, want %v", got, keys)
+	}
+
+	for q := -1; q <= 1201; q++ {
+		c.Seek(q)
+		i := sort.SearchInts(keys, q)
+
+		// Check the seek position and a few steps after it
+		for j := i; j < i+3; j++ {
+			if j >= len(keys) {
+				if c.Valid() {
+					t.Fatalf("Seek(%d): cursor valid past the end at %d", q, c.Key())
+				}
+
+				break
+			}
+
+			if !c.Valid() || c.Key() != keys[j] {
+				t.Fatalf("Seek(%d) step %d: want %d", q, j-i, keys[j])
+			}
+
+			c.Next()
+		}
+	}
+
+	c.First()
+
+	if !c.Valid() || c.Key() != keys[0] {
+		t.Error("First didn't reset the cursor")
+	}
+
+	if NewBinaryTree[int, int]().Cursor().Valid() {
+		t.Error("cursor over empty tree is valid")
+	}
+}
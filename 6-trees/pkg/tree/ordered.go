@@ -0,0 +1,153 @@
+package tree
+
+import (
+	"iter"
+)
+
+// Returns the key and value of the node, or false if it is nil.
+func (node *BinaryTreeNode[K, V]) entry() (K, V, bool) {
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+
+	return node.Key, node.Val, true
+}
+
+func (t *BinaryTree[K, V]) Min() (K, V, bool) {
+	node := t.Root
+
+	for node != nil && node.Left != nil {
+		node = node.Left
+	}
+
+	return node.entry()
+}
+
+func (t *BinaryTree[K, V]) Max() (K, V, bool) {
+	node := t.Root
+
+	for node != nil && node.Right != nil {
+		node = node.Right
+	}
+
+	return node.entry()
+}
+
+// Returns the node with the largest key below key, or the smallest key above
+// it if above is set. A node with key itself is returned if inclusive is set.
+func (node *BinaryTreeNode[K, V]) closest(key K, above, inclusive bool) *BinaryTreeNode[K, V] {
+	var best *BinaryTreeNode[K, V]
+
+	for node != nil {
+		if inclusive && node.Key == key {
+			return node
+		}
+
+		if above {
+			if node.Key > key {
+				best = node
+				node = node.Left
+			} else {
+				node = node.Right
+			}
+		} else {
+			if node.Key < key {
+				best = node
+				node = node.Right
+			} else {
+				node = node.Left
+			}
+		}
+	}
+
+	return best
+}
+
+// Returns the largest key less than or equal to key.
+func (t *BinaryTree[K, V]) Floor(key K) (K, V, bool) {
+	return t.Root.closest(key, false, true).entry()
+}
+
+// Returns the smallest key greater than or equal to key.
+func (t *BinaryTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return t.Root.closest(key, true, true).entry()
+}
+
+// Returns the largest key less than key.
+func (t *BinaryTree[K, V]) Predecessor(key K) (K, V, bool) {
+	return t.Root.closest(key, false, false).entry()
+}
+
+// Returns the smallest key greater than key.
+func (t *BinaryTree[K, V]) Successor(key K) (K, V, bool) {
+	return t.Root.closest(key, true, false).entry()
+}
+
+// Iterates over the keys in [lo, hi] in order.
+func (t *BinaryTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.Root.rangeKeys(lo, hi, yield)
+	}
+}
+
+// Returns false if yield stopped the iteration.
+func (node *BinaryTreeNode[K, V]) rangeKeys(lo, hi K, yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	if lo < node.Key && !node.Left.rangeKeys(lo, hi, yield) {
+		return false
+	}
+
+	if lo <= node.Key && node.Key <= hi && !yield(node.Key, node.Val) {
+		return false
+	}
+
+	if node.Key < hi {
+		return node.Right.rangeKeys(lo, hi, yield)
+	}
+
+	return true
+}
+
+// Returns the k-th smallest key, counting from 0.
+func (t *BinaryTree[K, V]) Select(k int) (K, V, bool) {
+	node := t.Root
+
+	for node != nil {
+		left := node.Left.Size()
+
+		if k < left {
+			node = node.Left
+		} else if k == left {
+			break
+		} else {
+			k -= left + 1
+			node = node.Right
+		}
+	}
+
+	return node.entry()
+}
+
+// Returns the number of keys less than key.
+func (t *BinaryTree[K, V]) Rank(key K) int {
+	rank := 0
+	node := t.Root
+
+	for node != nil {
+		if key < node.Key {
+			node = node.Left
+		} else if key == node.Key {
+			return rank + node.Left.Size()
+		} else {
+			rank += node.Left.Size() + 1
+			node = node.Right
+		}
+	}
+
+	return rank
+}
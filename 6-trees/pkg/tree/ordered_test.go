@@ -0,0 +1,156 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// Checks that every node's size matches its subtree.
+func checkSizes(t *testing.T, node *BinaryTreeNode[int, int]) int {
+	t.Helper()
+
+	if node == nil {
+		return 0
+	}
+
+	size := checkSizes(t, node.Left) + checkSizes(t, node.Right) + 1
+
+	if node.size != size {
+		t.Fatalf("node %d: stored size %d, actual %d", node.Key, node.size, size)
+	}
+
+	return size
+}
+
+// Compares a query result with the key at index i of keys, where i out of
+// range means the query should fail.
+func expectKey(t *testing.T, query string, keys []int, i, key, val int, ok bool) {
+	t.Helper()
+
+	if i < 0 || i >= len(keys) {
+		if ok {
+			t.Fatalf("%s: got %d, want nothing", query, key)
+		}
+
+		return
+	}
+
+	if !ok || key != keys[i] || val != -keys[i] {
+		t.Fatalf("%s: got %d, %d, %t, want %d", query, key, val, ok, keys[i])
+	}
+}
+
+func TestOrderedQueries(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewBinaryTree[int, int]()
+	present := map[int]bool{}
+
+	for round := 0; round < 50; round++ {
+		for i := 0; i < 40; i++ {
+			key := rng.Intn(200) * 2
+
+			if rng.Intn(3) == 0 {
+				tree.Delete(key)
+				delete(present, key)
+			} else {
+				tree.Add(key, -key)
+				present[key] = true
+			}
+		}
+
+		checkSizes(t, tree.Root)
+
+		keys := []int{}
+
+		for key := range present {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		k, v, ok := tree.Min()
+		expectKey(t, "Min", keys, 0, k, v, ok)
+		k, v, ok = tree.Max()
+		expectKey(t, "Max", keys, len(keys)-1, k, v, ok)
+
+		// Odd keys are never in the tree, so they test the queries between keys
+		for q := -1; q <= 401; q++ {
+			// Index of the first key >= q
+			i := sort.SearchInts(keys, q)
+			found := i < len(keys) && keys[i] == q
+
+			if rank := tree.Rank(q); rank != i {
+				t.Fatalf("Rank(%d): got %d, want %d", q, rank, i)
+			}
+
+			k, v, ok = tree.Ceiling(q)
+			expectKey(t, "Ceiling", keys, i, k, v, ok)
+			k, v, ok = tree.Predecessor(q)
+			expectKey(t, "Predecessor", keys, i-1, k, v, ok)
+
+			if found {
+				k, v, ok = tree.Floor(q)
+				expectKey(t, "Floor", keys, i, k, v, ok)
+				k, v, ok = tree.Successor(q)
+				expectKey(t, "Successor", keys, i+1, k, v, ok)
+			} else {
+				k, v, ok = tree.Floor(q)
+				expectKey(t, "Floor", keys, i-1, k, v, ok)
+				k, v, ok = tree.Successor(q)
+				expectKey(t, "Successor", keys, i, k, v, ok)
+			}
+		}
+
+		for i := -1; i <= len(keys); i++ {
+			k, v, ok = tree.Select(i)
+			expectKey(t, "Select", keys, i, k, v, ok)
+		}
+
+		lo, hi := rng.Intn(420)-10, rng.Intn(420)-10
+		want := []int{}
+
+		for _, key := range keys {
+			if lo <= key && key <= hi {
+				want = append(want, key)
+			}
+		}
+
+		got := []int{}
+
+		for key, val := range tree.Range(lo, hi) {
+			if val != -key {
+				t.Fatalf("Range: key %d has value %d", key, val)
+			}
+
+			got = append(got, key)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Fatalf("Range(%d, %d): got %v, want %v", lo, hi, got, want)
+		}
+	}
+}
+
+func TestRangeStop(t *testing.T) {
+	tree := NewBinaryTree[int, int]()
+
+	for _, key := range []int{5, 2, 8, 1, 3, 7, 9} {
+		tree.Add(key, key)
+	}
+
+	got := []int{}
+
+	for key := range tree.Range(0, 10) {
+		if key == 7 {
+			break
+		}
+
+		got = append(got, key)
+	}
+
+	if !slices.Equal(got, []int{1, 2, 3, 5}) {
+		t.Errorf("got %v, want [1 2 3 5]", got)
+	}
+}
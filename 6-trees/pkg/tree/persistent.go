@@ -0,0 +1,365 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+	"sync/atomic"
+)
+
+// Immutable AVL tree. Add and Delete copy only the nodes on the path to the
+// key and return a new tree sharing everything else with the old one, so
+// every version stays valid and can be read from any number of goroutines
+// without locking.
+type PersistentTree[K cmp.Ordered, V any] struct {
+	root *persistentNode[K, V]
+}
+
+type persistentNode[K cmp.Ordered, V any] struct {
+	key    K
+	val    V
+	left   *persistentNode[K, V]
+	right  *persistentNode[K, V]
+	height int
+	size   int
+}
+
+func NewPersistentTree[K cmp.Ordered, V any]() *PersistentTree[K, V] {
+	return &PersistentTree[K, V]{}
+}
+
+func (node *persistentNode[K, V]) getHeight() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.height
+}
+
+func (node *persistentNode[K, V]) getSize() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.size
+}
+
+func newPersistentNode[K cmp.Ordered, V any](key K, val V, left, right *persistentNode[K, V]) *persistentNode[K, V] {
+	return &persistentNode[K, V]{
+		key:    key,
+		val:    val,
+		left:   left,
+		right:  right,
+		height: max(left.getHeight(), right.getHeight()) + 1,
+		size:   left.getSize() + right.getSize() + 1,
+	}
+}
+
+// Builds a node from subtrees whose heights differ by at most two, rotating
+// if needed. Rotations create new nodes instead of changing the old ones.
+func balancePersistent[K cmp.Ordered, V any](key K, val V, left, right *persistentNode[K, V]) *persistentNode[K, V] {
+	lh, rh := left.getHeight(), right.getHeight()
+
+	if lh > rh+1 {
+		if left.left.getHeight() >= left.right.getHeight() {
+			return newPersistentNode(left.key, left.val, left.left, newPersistentNode(key, val, left.right, right))
+		}
+
+		lr := left.right
+		return newPersistentNode(lr.key, lr.val,
+			newPersistentNode(left.key, left.val, left.left, lr.left),
+			newPersistentNode(key, val, lr.right, right))
+	}
+
+	if rh > lh+1 {
+		if right.right.getHeight() >= right.left.getHeight() {
+			return newPersistentNode(right.key, right.val, newPersistentNode(key, val, left, right.left), right.right)
+		}
+
+		rl := right.left
+		return newPersistentNode(rl.key, rl.val,
+			newPersistentNode(key, val, left, rl.left),
+			newPersistentNode(right.key, right.val, rl.right, right.right))
+	}
+
+	return newPersistentNode(key, val, left, right)
+}
+
+func (t *PersistentTree[K, V]) Len() int {
+	return t.root.getSize()
+}
+
+func (t *PersistentTree[K, V]) Lookup(key K) (V, bool) {
+	node := t.root
+
+	for node != nil {
+		if node.key == key {
+			return node.val, true
+		}
+
+		if key < node.key {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Returns a new tree with the key set to val.
+func (t *PersistentTree[K, V]) Add(key K, val V) *PersistentTree[K, V] {
+	return &PersistentTree[K, V]{t.root.add(key, val)}
+}
+
+func (node *persistentNode[K, V]) add(key K, val V) *persistentNode[K, V] {
+	if node == nil {
+		return newPersistentNode[K, V](key, val, nil, nil)
+	}
+
+	if key == node.key {
+		return newPersistentNode(key, val, node.left, node.right)
+	}
+
+	if key < node.key {
+		return balancePersistent(node.key, node.val, node.left.add(key, val), node.right)
+	}
+
+	return balancePersistent(node.key, node.val, node.left, node.right.add(key, val))
+}
+
+// Returns a new tree without the key, or the same tree and false if the key
+// isn't in it.
+func (t *PersistentTree[K, V]) Delete(key K) (*PersistentTree[K, V], bool) {
+	root, ok := t.root.delete(key)
+
+	if !ok {
+		return t, false
+	}
+
+	return &PersistentTree[K, V]{root}, true
+}
+
+func (node *persistentNode[K, V]) delete(key K) (*persistentNode[K, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	if key < node.key {
+		left, ok := node.left.delete(key)
+
+		if !ok {
+			return node, false
+		}
+
+		return balancePersistent(node.key, node.val, left, node.right), true
+	}
+
+	if key > node.key {
+		right, ok := node.right.delete(key)
+
+		if !ok {
+			return node, false
+		}
+
+		return balancePersistent(node.key, node.val, node.left, right), true
+	}
+
+	if node.left == nil {
+		return node.right, true
+	}
+
+	if node.right == nil {
+		return node.left, true
+	}
+
+	// Replace the node with its successor
+	succ := node.right
+
+	for succ.left != nil {
+		succ = succ.left
+	}
+
+	right, _ := node.right.delete(succ.key)
+	return balancePersistent(succ.key, succ.val, node.left, right), true
+}
+
+// Iterates over the keys in order.
+func (t *PersistentTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		stack := []*persistentNode[K, V]{}
+		node := t.root
+
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.left
+			}
+
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if !yield(node.key, node.val) {
+				return
+			}
+
+			node = node.right
+		}
+	}
+}
+
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Modified
+)
+
+type Change[K cmp.Ordered, V any] struct {
+	Kind ChangeKind
+	Key  K
+	// Zero for added keys
+	Old V
+	// Zero for removed keys
+	New V
+}
+
+// Part of an in-order walk: either a whole subtree that hasn't been entered
+// yet, or a single node whose left subtree is done.
+type diffFrame[K cmp.Ordered, V any] struct {
+	node  *persistentNode[K, V]
+	whole bool
+}
+
+type diffWalk[K cmp.Ordered, V any] []diffFrame[K, V]
+
+func (w *diffWalk[K, V]) push(node *persistentNode[K, V], whole bool) {
+	if node != nil {
+		*w = append(*w, diffFrame[K, V]{node, whole})
+	}
+}
+
+func (w *diffWalk[K, V]) pop() diffFrame[K, V] {
+	f := (*w)[len(*w)-1]
+	*w = (*w)[:len(*w)-1]
+
+	return f
+}
+
+// Replaces the whole subtree on top with its left subtree, node and right
+// subtree.
+func (w *diffWalk[K, V]) expand() {
+	f := w.pop()
+	w.push(f.node.right, true)
+	w.push(f.node, false)
+	w.push(f.node.left, true)
+}
+
+// Iterates over the keys that differ between t and other, in order. Added and
+// removed are relative to t, and keys in both are reported as modified if eq
+// says their values differ. Subtrees shared by both versions are skipped, so
+// comparing a tree with a version a few changes away is fast.
+func (t *PersistentTree[K, V]) Diff(other *PersistentTree[K, V], eq func(a, b V) bool) iter.Seq[Change[K, V]] {
+	return func(yield func(Change[K, V]) bool) {
+		var a, b diffWalk[K, V]
+		a.push(t.root, true)
+		b.push(other.root, true)
+
+		for len(a) > 0 || len(b) > 0 {
+			if len(a) > 0 && len(b) > 0 {
+				fa, fb := a[len(a)-1], b[len(b)-1]
+
+				if fa.whole && fb.whole && fa.node == fb.node {
+					a.pop()
+					b.pop()
+					continue
+				}
+
+				// Expand the larger subtree first, as it may contain the
+				// other one
+				if fa.whole && (!fb.whole || fa.node.size >= fb.node.size) {
+					a.expand()
+					continue
+				}
+
+				if fb.whole {
+					b.expand()
+					continue
+				}
+
+				var c Change[K, V]
+
+				switch na, nb := fa.node, fb.node; {
+				case na.key < nb.key:
+					a.pop()
+					c = Change[K, V]{Kind: Removed, Key: na.key, Old: na.val}
+				case na.key > nb.key:
+					b.pop()
+					c = Change[K, V]{Kind: Added, Key: nb.key, New: nb.val}
+				default:
+					a.pop()
+					b.pop()
+
+					if na == nb || eq(na.val, nb.val) {
+						continue
+					}
+
+					c = Change[K, V]{Kind: Modified, Key: na.key, Old: na.val, New: nb.val}
+				}
+
+				if !yield(c) {
+					return
+				}
+
+				continue
+			}
+
+			// One side is done, everything left on the other differs
+			if len(a) > 0 {
+				if a[len(a)-1].whole {
+					a.expand()
+				} else if node := a.pop().node; !yield(Change[K, V]{Kind: Removed, Key: node.key, Old: node.val}) {
+					return
+				}
+			} else {
+				if b[len(b)-1].whole {
+					b.expand()
+				} else if node := b.pop().node; !yield(Change[K, V]{Kind: Added, Key: node.key, New: node.val}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Holds the current version of a persistent tree. Readers take snapshots
+// without locking, and writers replace the version atomically.
+type Versioned[K cmp.Ordered, V any] struct {
+	current atomic.Pointer[PersistentTree[K, V]]
+}
+
+func NewVersioned[K cmp.Ordered, V any]() *Versioned[K, V] {
+	v := &Versioned[K, V]{}
+	v.current.Store(NewPersistentTree[K, V]())
+
+	return v
+}
+
+// Returns the current version in O(1). It never changes, however the
+// Versioned is updated later.
+func (v *Versioned[K, V]) Snapshot() *PersistentTree[K, V] {
+	return v.current.Load()
+}
+
+// Replaces the current version with update applied to it. If another writer
+// got in between, update is run again on the newer version.
+func (v *Versioned[K, V]) Update(update func(*PersistentTree[K, V]) *PersistentTree[K, V]) {
+	for {
+		old := v.current.Load()
+
+		if v.current.CompareAndSwap(old, update(old)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package tree
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// Checks AVL balance, order and the cached sizes. Returns the height.
+func checkPersistent(t *testing.T, node *persistentNode[int, int], lo, hi int) int {
+	t.Helper()
+
+	if node == nil {
+		return 0
+	}
+
+	if node.key <= lo || node.key >= hi {
+		t.Fatalf("key %d outside (%d, %d)", node.key, lo, hi)
+	}
+
+	left := checkPersistent(t, node.left, lo, node.key)
+	right := checkPersistent(t, node.right, node.key, hi)
+
+	if left-right > 1 || right-left > 1 {
+		t.Fatalf("node %d: subtree heights %d and %d", node.key, left, right)
+	}
+
+	if node.height != max(left, right)+1 || node.size != node.left.getSize()+node.right.getSize()+1 {
+		t.Fatalf("node %d: wrong cached height or size", node.key)
+	}
+
+	return node.height
+}
+
+func expectVersion(t *testing.T, tree *PersistentTree[int, int], want map[int]int) {
+	t.Helper()
+
+	checkPersistent(t, tree.root, -1<<62, 1<<62)
+
+	if tree.Len() != len(want) {
+		t.Fatalf("Len: got %d, want %d", tree.Len(), len(want))
+	}
+
+	keys := []int{}
+
+	for key, val := range tree.All() {
+		if want[key] != val {
+			t.Fatalf("key %d: got %d, want %d", key, val, want[key])
+		}
+
+		if v, ok := tree.Lookup(key); !ok || v != val {
+			t.Fatalf("Lookup(%d): got %d, %t, want %d", key, v, ok, val)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if !slices.Equal(keys, slices.Sorted(maps.Keys(want))) {
+		t.Fatalf("keys: got %v", keys)
+	}
+}
+
+// Brute force diff of two maps, in key order.
+func diffMaps(a, b map[int]int) []Change[int, int] {
+	keys := slices.Sorted(maps.Keys(a))
+
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	changes := []Change[int, int]{}
+
+	for _, key := range keys {
+		va, inA := a[key]
+		vb, inB := b[key]
+
+		switch {
+		case !inB:
+			changes = append(changes, Change[int, int]{Removed, key, va, 0})
+		case !inA:
+			changes = append(changes, Change[int, int]{Added, key, 0, vb})
+		case va != vb:
+			changes = append(changes, Change[int, int]{Modified, key, va, vb})
+		}
+	}
+
+	return changes
+}
+
+func TestPersistentVersions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	versions := []*PersistentTree[int, int]{NewPersistentTree[int, int]()}
+	wants := []map[int]int{{}}
+
+	for i := 0; i < 3000; i++ {
+		tree := versions[len(versions)-1]
+		want := maps.Clone(wants[len(wants)-1])
+		key := rng.Intn(300)
+
+		if rng.Intn(3) == 0 {
+			next, ok := tree.Delete(key)
+			_, wok := want[key]
+
+			if ok != wok || (!ok && next != tree) {
+				t.Fatalf("Delete(%d): got %t, want %t", key, ok, wok)
+			}
+
+			tree = next
+			delete(want, key)
+		} else {
+			// Values often repeat so that some updates change nothing
+			val := rng.Intn(3)
+			tree = tree.Add(key, val)
+			want[key] = val
+		}
+
+		versions = append(versions, tree)
+		wants = append(wants, want)
+	}
+
+	// Every old version is still intact
+	for i := 0; i < len(versions); i += 97 {
+		expectVersion(t, versions[i], wants[i])
+	}
+
+	eq := func(a, b int) bool { return a == b }
+
+	for i := 0; i < 200; i++ {
+		a, b := rng.Intn(len(versions)), rng.Intn(len(versions))
+
+		if rng.Intn(2) == 0 {
+			// Nearby versions share most of their nodes
+			b = min(a+rng.Intn(5), len(versions)-1)
+		}
+
+		got := slices.Collect(versions[a].Diff(versions[b], eq))
+
+		if want := diffMaps(wants[a], wants[b]); !slices.Equal(got, want) {
+			t.Fatalf("Diff(%d, %d): got %v, want %v", a, b, got, want)
+		}
+	}
+}
+
+func TestDiffStop(t *testing.T) {
+	a := NewPersistentTree[int, int]()
+	b := a
+
+	for i := 0; i < 10; i++ {
+		b = b.Add(i, i)
+	}
+
+	n := 0
+
+	for c := range a.Diff(b, func(x, y int) bool { return x == y }) {
+		if c.Kind != Added {
+			t.Fatalf("got change %v, want an addition", c)
+		}
+
+		n++
+
+		if n == 3 {
+			break
+		}
+	}
+
+	if n != 3 {
+		t.Errorf("Diff stopped after %d changes", n)
+	}
+}
+
+func TestVersionedConcurrent(t *testing.T) {
+	v := NewVersioned[int, int]()
+	var wg sync.WaitGroup
+
+	// Writers each add their own keys while readers check that every
+	// snapshot is a consistent tree
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 250; i++ {
+				key := w*1000 + i
+
+				v.Update(func(tree *PersistentTree[int, int]) *PersistentTree[int, int] {
+					return tree.Add(key, key)
+				})
+			}
+		}()
+	}
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				snap := v.Snapshot()
+				n := 0
+
+				for key, val := range snap.All() {
+					if key != val {
+						t.Errorf("key %d has value %d", key, val)
+						return
+					}
+
+					n++
+				}
+
+				if n != snap.Len() {
+					t.Errorf("snapshot of length %d has %d keys", snap.Len(), n)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if v.Snapshot().Len() != 1000 {
+		t.Errorf("got %d keys, want 1000", v.Snapshot().Len())
+	}
+}
@@ -0,0 +1,110 @@
+package tree
+
+import (
+	"cmp"
+)
+
+// Left-leaning red-black tree (Sedgewick). Red links always lean left, so a
+// node is red if the link from its parent is red, and every path from the
+// root to a leaf passes through the same number of black links.
+type RedBlackTreeNode[K cmp.Ordered, V any] struct {
+	Key   K
+	Val   V
+	Left  *RedBlackTreeNode[K, V]
+	Right *RedBlackTreeNode[K, V]
+	red   bool
+}
+
+type RedBlackTree[K cmp.Ordered, V any] struct {
+	Root *RedBlackTreeNode[K, V]
+}
+
+func NewRedBlackTree[K cmp.Ordered, V any]() *RedBlackTree[K, V] {
+	return &RedBlackTree[K, V]{nil}
+}
+
+func (t *RedBlackTree[K, V]) Lookup(key K) (V, bool) {
+	return t.Root.lookup(key)
+}
+
+func (node *RedBlackTreeNode[K, V]) lookup(key K) (V, bool) {
+	for node != nil {
+		if node.Key == key {
+			return node.Val, true
+		}
+
+		if key < node.Key {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (t *RedBlackTree[K, V]) Add(key K, val V) {
+	t.Root = t.Root.add(key, val)
+	t.Root.red = false
+}
+
+func (node *RedBlackTreeNode[K, V]) isRed() bool {
+	return node != nil && node.red
+}
+
+// Returns the root of the subtree after adding the key.
+func (node *RedBlackTreeNode[K, V]) add(key K, val V) *RedBlackTreeNode[K, V] {
+	if node == nil {
+		return &RedBlackTreeNode[K, V]{Key: key, Val: val, red: true}
+	}
+
+	if node.Key == key {
+		node.Val = val
+	} else if key < node.Key {
+		node.Left = node.Left.add(key, val)
+	} else {
+		node.Right = node.Right.add(key, val)
+	}
+
+	if node.Right.isRed() && !node.Left.isRed() {
+		node = node.rotateLeft()
+	}
+
+	if node.Left.isRed() && node.Left.Left.isRed() {
+		node = node.rotateRight()
+	}
+
+	if node.Left.isRed() && node.Right.isRed() {
+		node.flipColors()
+	}
+
+	return node
+}
+
+func (node *RedBlackTreeNode[K, V]) rotateLeft() *RedBlackTreeNode[K, V] {
+	right := node.Right
+	node.Right = right.Left
+	right.Left = node
+	right.red = node.red
+	node.red = true
+
+	return right
+}
+
+func (node *RedBlackTreeNode[K, V]) rotateRight() *RedBlackTreeNode[K, V] {
+	left := node.Left
+	node.Left = left.Right
+	left.Right = node
+	left.red = node.red
+	node.red = true
+
+	return left
+}
+
+// Splits a temporary 4-node by passing its red link up to the parent.
+func (node *RedBlackTreeNode[K, V]) flipColors() {
+	node.red = true
+	node.Left.red = false
+	node.Right.red = false
+}
@@ -0,0 +1,62 @@
+package tree
+
+// Shape of a tree. The root is at depth 0 and the height is the number of
+// levels, so an empty tree has height 0 and a single node height 1.
+type Stats struct {
+	Nodes    int
+	Leaves   int
+	Height   int
+	MaxDepth int
+	AvgDepth float64
+	// Number of nodes with each balance factor, the height of the right
+	// subtree minus the height of the left
+	BalanceFactors map[int]int
+	// Number of nodes at each depth
+	DepthHistogram []int
+}
+
+func (t *BinaryTree[K, V]) Stats() Stats {
+	s := Stats{BalanceFactors: map[int]int{}, DepthHistogram: []int{}}
+	t.Root.stats(&s, 0)
+
+	if s.Nodes == 0 {
+		return s
+	}
+
+	totalDepth := 0
+
+	for depth, count := range s.DepthHistogram {
+		totalDepth += depth * count
+	}
+
+	s.Height = len(s.DepthHistogram)
+	s.MaxDepth = s.Height - 1
+	s.AvgDepth = float64(totalDepth) / float64(s.Nodes)
+
+	return s
+}
+
+// Adds the subtree at depth to s and returns its height.
+func (node *BinaryTreeNode[K, V]) stats(s *Stats, depth int) int {
+	if node == nil {
+		return 0
+	}
+
+	s.Nodes++
+
+	if depth == len(s.DepthHistogram) {
+		s.DepthHistogram = append(s.DepthHistogram, 0)
+	}
+
+	s.DepthHistogram[depth]++
+
+	if node.Left == nil && node.Right == nil {
+		s.Leaves++
+	}
+
+	left := node.Left.stats(s, depth+1)
+	right := node.Right.stats(s, depth+1)
+	s.BalanceFactors[right-left]++
+
+	return max(left, right) + 1
+}
@@ -0,0 +1,53 @@
+package tree
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	if s := NewBinaryTree[int, int]().Stats(); s.Nodes != 0 || s.Height != 0 || s.AvgDepth != 0 || len(s.DepthHistogram) != 0 {
+		t.Errorf("empty tree: got %+v", s)
+	}
+
+	//        5
+	//     3     8
+	//    2 4     9
+	//             10
+	tree := NewBinaryTree[int, int]()
+
+	for _, key := range []int{5, 3, 8, 2, 4, 9, 10} {
+		tree.Add(key, key)
+	}
+
+	s := tree.Stats()
+
+	if s.Nodes != 7 || s.Leaves != 3 || s.Height != 4 || s.MaxDepth != 3 {
+		t.Errorf("got %d nodes, %d leaves, height %d, max depth %d", s.Nodes, s.Leaves, s.Height, s.MaxDepth)
+	}
+
+	if !slices.Equal(s.DepthHistogram, []int{1, 2, 3, 1}) {
+		t.Errorf("depth histogram: got %v", s.DepthHistogram)
+	}
+
+	if want := float64(0+1+1+2+2+2+3) / 7; s.AvgDepth != want {
+		t.Errorf("average depth: got %f, want %f", s.AvgDepth, want)
+	}
+
+	// 5: 3-2, 3: 0, 8: 2-0, 9: 1-0, leaves: 0
+	if want := map[int]int{1: 2, 0: 4, 2: 1}; !maps.Equal(s.BalanceFactors, want) {
+		t.Errorf("balance factors: got %v, want %v", s.BalanceFactors, want)
+	}
+
+	// A path of sorted keys
+	path := NewBinaryTree[int, int]()
+
+	for key := range 5 {
+		path.Add(key, key)
+	}
+
+	if s := path.Stats(); s.Height != 5 || s.Leaves != 1 || s.BalanceFactors[4] != 1 {
+		t.Errorf("path: got %+v", s)
+	}
+}
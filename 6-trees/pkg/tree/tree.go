@@ -0,0 +1,9 @@
+package tree
+
+import "cmp"
+
+// Implemented by all the trees in the package.
+type Tree[K cmp.Ordered, V any] interface {
+	Lookup(key K) (V, bool)
+	Add(key K, val V)
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"cmp"
 	"encoding/csv"
 	"fmt"
-	"github.com/phanty133/id1021/7-quicksort/pkg/llist"
+	"github.com/phanty133/id1021/3-sorted/pkg/sorting"
+	"github.com/phanty133/id1021/containers/llist"
 	"math/rand"
 	"os"
 	"time"
@@ -122,6 +124,98 @@ func partitionLinkedList(min, max *llist.LinkedListItem[int]) *llist.LinkedListI
 	return pivot
 }
 
+// Instrumented variants of the quicksorts above. They make the same
+// comparisons and swaps, but go through a sorting.Comparator so the work can
+// be counted. Only the counting runs use them, so the timed runs don't pay for
+// the indirect comparisons.
+
+func partitionArrayFunc[T any](array []T, min, max int, c sorting.Comparator[T]) int {
+	pivot := array[min]
+	i := min
+	j := max
+
+	for i < j {
+		for !c.Less(pivot, array[i]) && i < max {
+			i++
+		}
+
+		for c.Less(pivot, array[j]) && j > min {
+			j--
+		}
+
+		if i < j {
+			c.Swap(array, i, j)
+		}
+	}
+
+	c.Swap(array, min, j)
+
+	return j
+}
+
+func QuickSortArrayFunc[T any](array []T, c sorting.Comparator[T]) {
+	quickSortArrayFuncRec(array, 0, len(array)-1, c)
+}
+
+func quickSortArrayFuncRec[T any](array []T, min, max int, c sorting.Comparator[T]) {
+	if min >= max {
+		return
+	}
+
+	c.Enter()
+	defer c.Exit()
+
+	pivot := partitionArrayFunc(array, min, max, c)
+
+	quickSortArrayFuncRec(array, min, pivot-1, c)
+	quickSortArrayFuncRec(array, pivot+1, max, c)
+}
+
+func QuickSortLinkedListFunc[T comparable](list *llist.LinkedList[T], c sorting.Comparator[T]) {
+	quickSortLinkedListFuncRec(list.First(), list.Last(), c)
+}
+
+func quickSortLinkedListFuncRec[T comparable](min, max *llist.LinkedListItem[T], c sorting.Comparator[T]) {
+	if min == max {
+		return
+	}
+
+	c.Enter()
+	defer c.Exit()
+
+	pivot := partitionLinkedListFunc(min, max, c)
+
+	if pivot != nil {
+		if pivot.Next() != nil {
+			quickSortLinkedListFuncRec(pivot.Next(), max, c)
+		}
+
+		if pivot != min {
+			quickSortLinkedListFuncRec(min, pivot, c)
+		}
+	}
+}
+
+func partitionLinkedListFunc[T comparable](min, max *llist.LinkedListItem[T], c sorting.Comparator[T]) *llist.LinkedListItem[T] {
+	pivot := min
+	i := min
+
+	for i != max && i != nil {
+		if c.Less(i.Head, max.Head) {
+			pivot = min
+
+			c.SwapValues(&i.Head, &min.Head)
+
+			min = min.Next()
+		}
+
+		i = i.Next()
+	}
+
+	c.SwapValues(&min.Head, &max.Head)
+	return pivot
+}
+
 func BenchmarkQuickSortArray(arr []int) int64 {
 	start := time.Now()
 	QuickSortArrayInPlace(arr)
@@ -134,7 +228,29 @@ func BenchmarkQuickSortLinkedList(ll *llist.LinkedList[int]) int64 {
 	return time.Since(start).Nanoseconds()
 }
 
-func WriteTimes(name string, times [][]int64, sizes []int) {
+func CountQuickSortArray(arr []int) sorting.Stats {
+	stats := sorting.Stats{}
+	c := sorting.Instrument(cmp.Compare[int], &stats)
+	stats.Track(func() { QuickSortArrayFunc(arr, c) })
+	return stats
+}
+
+func CountQuickSortLinkedList(ll *llist.LinkedList[int]) sorting.Stats {
+	stats := sorting.Stats{}
+	c := sorting.Instrument(cmp.Compare[int], &stats)
+	stats.Track(func() { QuickSortLinkedListFunc(ll, c) })
+	return stats
+}
+
+var statNames = []string{"comparisons", "swaps", "depth", "bytes"}
+
+func statValues(s sorting.Stats) []int64 {
+	return []int64{s.Comparisons, s.Swaps, int64(s.MaxDepth), int64(s.Bytes)}
+}
+
+// Writes a row per repeat with the times for each size, followed by the
+// counts for each size.
+func WriteTimes(name string, times [][]int64, sizes []int, stats [][]sorting.Stats) {
 	arrFile, err := os.Create(name)
 
 	if err != nil {
@@ -151,6 +267,12 @@ func WriteTimes(name string, times [][]int64, sizes []int) {
 		header[i+1] = fmt.Sprintf("%d", size)
 	}
 
+	for _, stat := range statNames {
+		for _, size := range sizes {
+			header = append(header, fmt.Sprintf("%s %d", stat, size))
+		}
+	}
+
 	if err := arrWriter.Write(header); err != nil {
 		panic(err)
 	}
@@ -163,6 +285,12 @@ func WriteTimes(name string, times [][]int64, sizes []int) {
 			row[j+1] = fmt.Sprintf("%d", times[j][i])
 		}
 
+		for k := range statNames {
+			for j := range sizes {
+				row = append(row, fmt.Sprintf("%d", statValues(stats[j][i])[k]))
+			}
+		}
+
 		if err := arrWriter.Write(row); err != nil {
 			panic(err)
 		}
@@ -188,17 +316,25 @@ func main() {
 	repeats := 100
 	arrTimes := make([][]int64, len(objSizes))
 	llTimes := make([][]int64, len(objSizes))
+	arrStats := make([][]sorting.Stats, len(objSizes))
+	llStats := make([][]sorting.Stats, len(objSizes))
 
 	for i, size := range objSizes {
 		fmt.Printf("Size: %d\n", size)
 
 		arrTimes[i] = make([]int64, repeats)
 		llTimes[i] = make([]int64, repeats)
+		arrStats[i] = make([]sorting.Stats, repeats)
+		llStats[i] = make([]sorting.Stats, repeats)
 
 		for j := 0; j < repeats; j++ {
 			arr := GenRandIntArr(size, 0, 1000000)
 			ll := ArrToLinkedList(arr)
 
+			// Count on copies so the timed runs aren't slowed down
+			arrStats[i][j] = CountQuickSortArray(append([]int{}, arr...))
+			llStats[i][j] = CountQuickSortLinkedList(ArrToLinkedList(arr))
+
 			arrTimes[i][j] = BenchmarkQuickSortArray(arr)
 			llTimes[i][j] = BenchmarkQuickSortLinkedList(ll)
 
@@ -212,6 +348,6 @@ func main() {
 		}
 	}
 
-	WriteTimes("array.csv", arrTimes, objSizes)
-	WriteTimes("linkedlist.csv", llTimes, objSizes)
+	WriteTimes("array.csv", arrTimes, objSizes, arrStats)
+	WriteTimes("linkedlist.csv", llTimes, objSizes, llStats)
 }
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/phanty133/id1021/3-sorted/pkg/sortcheck"
+	"github.com/phanty133/id1021/3-sorted/pkg/sorting"
+)
+
+func sortLinkedList(arr []int) {
+	ll := ArrToLinkedList(arr)
+	QuickSortLinkedList(ll)
+	copy(arr, LinkedListToArr(ll))
+}
+
+var sorts = map[string]func([]int){
+	"Array":      QuickSortArrayInPlace,
+	"LinkedList": sortLinkedList,
+	"ArrayFunc": func(arr []int) {
+		QuickSortArrayFunc(arr, sorting.NewComparator(cmp.Compare[int]))
+	},
+	"LinkedListFunc": func(arr []int) {
+		ll := ArrToLinkedList(arr)
+		QuickSortLinkedListFunc(ll, sorting.NewComparator(cmp.Compare[int]))
+		copy(arr, LinkedListToArr(ll))
+	},
+}
+
+func TestQuickSorts(t *testing.T) {
+	for name, sort := range sorts {
+		t.Run(name, func(t *testing.T) {
+			sortcheck.Run(t, sort, sortcheck.IntGenerators(0))
+		})
+	}
+}
+
+func FuzzQuickSorts(f *testing.F) {
+	sortcheck.Fuzz(f, sorts)
+}
@@ -1,3 +1,13 @@
 module github.com/phanty133/id1021/7-quicksort
 
-go 1.21
+go 1.23
+
+require (
+	github.com/phanty133/id1021/3-sorted v0.0.0
+	github.com/phanty133/id1021/containers v0.0.0
+)
+
+replace (
+	github.com/phanty133/id1021/3-sorted => ../3-sorting
+	github.com/phanty133/id1021/containers => ../containers
+)
@@ -2,9 +2,13 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
+	"runtime"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/phanty133/id1021/8-queue/pkg/queue"
@@ -21,6 +25,12 @@ func GenRandIntArr(n, min, max int) []int {
 }
 
 func WriteTimes(name string, times [][]int64, sizes []int) {
+	WriteColumns(name, "Size", times, sizes)
+}
+
+// Writes one column per value of the varied parameter, named by label, and
+// one row per repeat.
+func WriteColumns(name string, label string, times [][]int64, sizes []int) {
 	arrFile, err := os.Create(name)
 
 	if err != nil {
@@ -31,7 +41,7 @@ func WriteTimes(name string, times [][]int64, sizes []int) {
 
 	arrWriter := csv.NewWriter(arrFile)
 	header := make([]string, len(sizes)+1)
-	header[0] = "Size"
+	header[0] = label
 
 	for i, size := range sizes {
 		header[i+1] = fmt.Sprintf("%d", size)
@@ -85,7 +95,128 @@ func Bench(getQueue func() queue.Queue[int], els []int) (int64, int64) {
 	return time1, time2
 }
 
+/*
+Throughput benchmark:
+- Move a fixed number of elements from P producers to P consumers
+- P = 1 to N, SPSC only at P = 1
+- N is -producers, the number of CPUs by default
+- Writes throughput-<queue>.csv with one column per P. The results depend on
+  the machine, so they aren't kept in the repository.
+*/
+
+// New returns the queue's enqueue and dequeue, which return false when the
+// operation has to be retried. Blocking queues and channels never fail.
+type ConcurrentQueue struct {
+	Name    string
+	New     func() (enqueue func(int) bool, dequeue func() bool)
+	MaxProd int
+}
+
+// Returns how long it takes the given number of producers to pass n elements
+// through the queue to the same number of consumers.
+func BenchThroughput(newQueue func() (func(int) bool, func() bool), producers, n int) int64 {
+	enqueue, dequeue := newQueue()
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for p := 0; p < producers; p++ {
+		count := n / producers
+
+		if p == 0 {
+			count += n % producers
+		}
+
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < count; i++ {
+				for !enqueue(i) {
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < count; i++ {
+				for !dequeue() {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return time.Since(start).Nanoseconds()
+}
+
+func RunThroughput(maxProducers int) {
+	n := 1000000
+	queueSize := 1024
+	repeats := 20
+
+	queues := []ConcurrentQueue{
+		{"channel", func() (func(int) bool, func() bool) {
+			ch := make(chan int, queueSize)
+			return func(v int) bool { ch <- v; return true }, func() bool { <-ch; return true }
+		}, maxProducers},
+		{"blocking", func() (func(int) bool, func() bool) {
+			q := queue.NewBlockingQueue[int](queueSize)
+			return func(v int) bool { return q.Enqueue(v) == nil }, func() bool { _, err := q.Dequeue(); return err == nil }
+		}, maxProducers},
+		{"mpmc", func() (func(int) bool, func() bool) {
+			q := queue.NewMPMCQueue[int](queueSize)
+			return func(v int) bool { return q.Enqueue(v) == nil }, func() bool { _, err := q.Dequeue(); return err == nil }
+		}, maxProducers},
+		{"spsc", func() (func(int) bool, func() bool) {
+			q := queue.NewSPSCQueue[int](queueSize)
+			return func(v int) bool { return q.Enqueue(v) == nil }, func() bool { _, err := q.Dequeue(); return err == nil }
+		}, 1},
+	}
+
+	for _, cq := range queues {
+		producers := make([]int, min(cq.MaxProd, maxProducers))
+		times := make([][]int64, len(producers))
+
+		for i := range producers {
+			producers[i] = i + 1
+			times[i] = make([]int64, repeats)
+
+			for j := 0; j < repeats; j++ {
+				times[i][j] = BenchThroughput(cq.New, producers[i], n)
+			}
+
+			best := slices.Min(times[i])
+			fmt.Printf("%s, %d producers: %.1f M elements/s\n", cq.Name, producers[i], float64(n)/float64(best)*1000)
+		}
+
+		WriteColumns(fmt.Sprintf("throughput-%s.csv", cq.Name), "Producers", times, producers)
+	}
+}
+
 func main() {
+	mode := flag.String("mode", "sequential", "benchmark to run: sequential or throughput")
+	producers := flag.Int("producers", runtime.NumCPU(), "largest number of producers in the throughput benchmark")
+	flag.Parse()
+
+	switch *mode {
+	case "sequential":
+		RunSequential()
+	case "throughput":
+		RunThroughput(*producers)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown mode %q\n", *mode)
+		os.Exit(2)
+	}
+}
+
+func RunSequential() {
 	sizes := []int{100, 1000, 5000, 10000, 50000, 100000}
 	repeats := 100
 	// dynamicInitSize := 4
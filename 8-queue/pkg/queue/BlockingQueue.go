@@ -0,0 +1,196 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// Bounded queue that is safe for any number of producers and consumers.
+// Enqueue blocks while the queue is full and Dequeue while it's empty.
+//
+// After Close, enqueues fail with ErrClosed and dequeues drain the remaining
+// elements before failing with ErrClosed too.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	notFull  sync.Cond
+	notEmpty sync.Cond
+	data     *QueueStatic[T]
+	closed   bool
+}
+
+func NewBlockingQueue[T any](size int) *BlockingQueue[T] {
+	q := &BlockingQueue[T]{
+		data: NewQueueStatic[T](max(size, 1)),
+	}
+
+	q.notFull.L = &q.mu
+	q.notEmpty.L = &q.mu
+
+	return q
+}
+
+// Waits on c until ready returns true, the queue is closed or ctx is done.
+// Must be called with the lock held.
+func (q *BlockingQueue[T]) wait(ctx context.Context, c *sync.Cond, ready func() bool) error {
+	if ready() || q.closed {
+		return nil
+	}
+
+	// Cond can't select on ctx, so wake the waiters up when it's cancelled
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		c.Broadcast()
+		q.mu.Unlock()
+	})
+
+	defer stop()
+
+	for !ready() && !q.closed {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		c.Wait()
+	}
+
+	return nil
+}
+
+// Blocks until there's space for val. Fails with ErrClosed if the queue is
+// closed.
+func (q *BlockingQueue[T]) Enqueue(val T) error {
+	return q.EnqueueCtx(context.Background(), val)
+}
+
+// Blocks until there's space for val or ctx is done, in which case the
+// context's error is returned.
+func (q *BlockingQueue[T]) EnqueueCtx(ctx context.Context, val T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.wait(ctx, &q.notFull, q.notFullLocked); err != nil {
+		return err
+	}
+
+	return q.enqueueLocked(val)
+}
+
+// Enqueues val without blocking. Fails with ErrFull if there's no space.
+func (q *BlockingQueue[T]) TryEnqueue(val T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.enqueueLocked(val)
+}
+
+func (q *BlockingQueue[T]) notFullLocked() bool {
+	return q.data.Len() < q.data.Cap()
+}
+
+func (q *BlockingQueue[T]) enqueueLocked(val T) error {
+	if q.closed {
+		return ErrClosed
+	}
+
+	if err := q.data.Enqueue(val); err != nil {
+		return ErrFull
+	}
+
+	q.notEmpty.Signal()
+
+	return nil
+}
+
+// Blocks until there's an element to dequeue. Fails with ErrClosed once the
+// queue is closed and drained.
+func (q *BlockingQueue[T]) Dequeue() (T, error) {
+	return q.DequeueCtx(context.Background())
+}
+
+// Blocks until there's an element to dequeue or ctx is done, in which case
+// the context's error is returned.
+func (q *BlockingQueue[T]) DequeueCtx(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.wait(ctx, &q.notEmpty, q.notEmptyLocked); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return q.dequeueLocked()
+}
+
+// Dequeues without blocking. Fails with ErrEmpty if there's nothing queued.
+func (q *BlockingQueue[T]) TryDequeue() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dequeueLocked()
+}
+
+func (q *BlockingQueue[T]) notEmptyLocked() bool {
+	return !q.data.Empty()
+}
+
+func (q *BlockingQueue[T]) dequeueLocked() (T, error) {
+	val, err := q.data.Dequeue()
+
+	if err != nil {
+		if q.closed {
+			return val, ErrClosed
+		}
+
+		return val, ErrEmpty
+	}
+
+	q.notFull.Signal()
+
+	return val, nil
+}
+
+// Returns the front element without removing it or blocking.
+func (q *BlockingQueue[T]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	val, err := q.data.Peek()
+
+	if err != nil {
+		return val, ErrEmpty
+	}
+
+	return val, nil
+}
+
+// Stops further enqueues and wakes up every blocked goroutine. Fails with
+// ErrClosed if the queue was already closed.
+func (q *BlockingQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	q.closed = true
+	q.notFull.Broadcast()
+	q.notEmpty.Broadcast()
+
+	return nil
+}
+
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.data.Len()
+}
+
+func (q *BlockingQueue[T]) Cap() int {
+	return q.data.Cap()
+}
+
+func (q *BlockingQueue[T]) Empty() bool {
+	return q.Len() == 0
+}
@@ -0,0 +1,262 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueueSequential(t *testing.T) {
+	q := NewBlockingQueue[int](4)
+
+	for i := 0; i < 4; i++ {
+		if err := q.TryEnqueue(i); err != nil {
+			t.Fatalf("TryEnqueue: %v", err)
+		}
+	}
+
+	if err := q.TryEnqueue(4); err != ErrFull {
+		t.Fatalf("TryEnqueue on a full queue: got %v, want ErrFull", err)
+	}
+
+	if v, err := q.Peek(); err != nil || v != 0 {
+		t.Fatalf("Peek: got %d, %v, want 0", v, err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if v, err := q.TryDequeue(); err != nil || v != i {
+			t.Fatalf("TryDequeue: got %d, %v, want %d", v, err, i)
+		}
+	}
+
+	if _, err := q.TryDequeue(); err != ErrEmpty {
+		t.Fatalf("TryDequeue on an empty queue: got %v, want ErrEmpty", err)
+	}
+
+	if !q.Empty() || q.Cap() != 4 {
+		t.Fatalf("got Len %d, Cap %d, want 0, 4", q.Len(), q.Cap())
+	}
+}
+
+func TestBlockingQueueCancel(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	q.Enqueue(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := q.EnqueueCtx(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("EnqueueCtx on a full queue: got %v, want DeadlineExceeded", err)
+	}
+
+	q.Dequeue()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		_, err := q.DequeueCtx(ctx)
+		done <- err
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("DequeueCtx on an empty queue: got %v, want Canceled", err)
+	}
+
+	// A cancelled waiter must not have swallowed anything
+	q.Enqueue(3)
+
+	if v, err := q.TryDequeue(); err != nil || v != 3 {
+		t.Fatalf("TryDequeue: got %d, %v, want 3", v, err)
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	q.Enqueue(1)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+
+	// One consumer gets the element, the rest block until the close
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := q.Dequeue()
+			errs <- err
+		}()
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var closed int
+
+	for err := range errs {
+		if err == ErrClosed {
+			closed++
+		} else if err != nil {
+			t.Fatalf("Dequeue: %v", err)
+		}
+	}
+
+	if closed != 3 {
+		t.Fatalf("%d dequeues failed with ErrClosed, want 3", closed)
+	}
+
+	if err := q.Enqueue(2); err != ErrClosed {
+		t.Fatalf("Enqueue after Close: got %v, want ErrClosed", err)
+	}
+
+	if err := q.Close(); err != ErrClosed {
+		t.Fatalf("second Close: got %v, want ErrClosed", err)
+	}
+}
+
+func TestBlockingQueueDrain(t *testing.T) {
+	q := NewBlockingQueue[int](4)
+	q.Enqueue(0)
+	q.Enqueue(1)
+	q.Close()
+
+	for i := 0; i < 2; i++ {
+		if v, err := q.Dequeue(); err != nil || v != i {
+			t.Fatalf("Dequeue after Close: got %d, %v, want %d", v, err, i)
+		}
+	}
+
+	if _, err := q.Dequeue(); err != ErrClosed {
+		t.Fatalf("Dequeue on a drained queue: got %v, want ErrClosed", err)
+	}
+}
+
+func TestBlockingQueueConcurrent(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 5000
+
+	q := NewBlockingQueue[int](16)
+	seen := make([]int, producers*perProducer)
+
+	var prodWg, consWg sync.WaitGroup
+	var mu sync.Mutex
+
+	for p := 0; p < producers; p++ {
+		prodWg.Add(1)
+
+		go func() {
+			defer prodWg.Done()
+
+			for i := 0; i < perProducer; i++ {
+				if err := q.Enqueue(p*perProducer + i); err != nil {
+					t.Errorf("Enqueue: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for c := 0; c < consumers; c++ {
+		consWg.Add(1)
+
+		go func() {
+			defer consWg.Done()
+
+			// Values from one producer must come out in order
+			last := make([]int, producers)
+
+			for i := range last {
+				last[i] = -1
+			}
+
+			for {
+				v, err := q.Dequeue()
+
+				if err == ErrClosed {
+					return
+				}
+
+				if v%perProducer <= last[v/perProducer] {
+					t.Errorf("got %d after %d from the same producer", v, last[v/perProducer])
+				}
+
+				last[v/perProducer] = v % perProducer
+
+				mu.Lock()
+				seen[v]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	prodWg.Wait()
+	q.Close()
+	consWg.Wait()
+
+	for v, n := range seen {
+		if n != 1 {
+			t.Fatalf("value %d dequeued %d times", v, n)
+		}
+	}
+}
+
+const benchQueueSize = 1024
+
+// Moves b.N values from 4 producers to 4 consumers.
+func benchmarkMPMC(b *testing.B, enqueue func(int), dequeue func()) {
+	const workers = 4
+
+	var wg sync.WaitGroup
+
+	b.ResetTimer()
+
+	for w := 0; w < workers; w++ {
+		n := b.N / workers
+
+		if w == 0 {
+			n += b.N % workers
+		}
+
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < n; i++ {
+				enqueue(i)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < n; i++ {
+				dequeue()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkBlockingQueue(b *testing.B) {
+	q := NewBlockingQueue[int](benchQueueSize)
+	benchmarkMPMC(b, func(v int) { q.Enqueue(v) }, func() { q.Dequeue() })
+}
+
+func BenchmarkChannel(b *testing.B) {
+	ch := make(chan int, benchQueueSize)
+	benchmarkMPMC(b, func(v int) { ch <- v }, func() { <-ch })
+}
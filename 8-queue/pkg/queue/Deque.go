@@ -0,0 +1,165 @@
+package queue
+
+import (
+	"iter"
+)
+
+// Double-ended queue on a growable circular buffer, like QueueDynamic but
+// with pushes and pops at both ends and O(1) indexed access.
+type Deque[T any] struct {
+	data    []T
+	front   int
+	count   int
+	minSize int
+}
+
+func NewDeque[T any](size int) *Deque[T] {
+	size = max(size, 1)
+
+	return &Deque[T]{
+		data:    make([]T, size),
+		minSize: size,
+	}
+}
+
+// Maps a position relative to the front to an index into the buffer.
+func (d *Deque[T]) index(i int) int {
+	return mod(d.front+i, len(d.data))
+}
+
+// Moves the elements into a new buffer of the given size, unwrapping them so
+// the front is at index 0. The size is raised to fit the elements, and to at
+// least 1.
+func (d *Deque[T]) Reallocate(newSize int) {
+	newData := make([]T, max(newSize, d.count, 1))
+
+	if d.front+d.count <= len(d.data) {
+		copy(newData, d.data[d.front:d.front+d.count])
+	} else {
+		endDist := copy(newData, d.data[d.front:])
+		copy(newData[endDist:], d.data[:d.count-endDist])
+	}
+
+	d.front = 0
+	d.data = newData
+}
+
+func (d *Deque[T]) grow() {
+	if d.count == len(d.data) {
+		d.Reallocate(len(d.data) * 2)
+	}
+}
+
+func (d *Deque[T]) shrink() {
+	if d.count < len(d.data)/4 && len(d.data)/2 >= d.minSize {
+		d.Reallocate(len(d.data) / 2)
+	}
+}
+
+func (d *Deque[T]) PushFront(val T) {
+	d.grow()
+	d.front = d.index(-1)
+	d.data[d.front] = val
+	d.count++
+}
+
+func (d *Deque[T]) PushBack(val T) {
+	d.grow()
+	d.data[d.index(d.count)] = val
+	d.count++
+}
+
+func (d *Deque[T]) PopFront() (T, error) {
+	var zero T
+
+	if d.Empty() {
+		return zero, ErrEmpty
+	}
+
+	val := d.data[d.front]
+	d.data[d.front] = zero
+	d.front = d.index(1)
+	d.count--
+	d.shrink()
+
+	return val, nil
+}
+
+func (d *Deque[T]) PopBack() (T, error) {
+	var zero T
+
+	if d.Empty() {
+		return zero, ErrEmpty
+	}
+
+	back := d.index(d.count - 1)
+	val := d.data[back]
+	d.data[back] = zero
+	d.count--
+	d.shrink()
+
+	return val, nil
+}
+
+func (d *Deque[T]) PeekFront() (T, error) {
+	if d.Empty() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return d.data[d.front], nil
+}
+
+func (d *Deque[T]) PeekBack() (T, error) {
+	if d.Empty() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return d.data[d.index(d.count-1)], nil
+}
+
+// Returns the i-th element counting from the front.
+func (d *Deque[T]) At(i int) (T, error) {
+	if i < 0 || i >= d.count {
+		var zero T
+		return zero, ErrIndex
+	}
+
+	return d.data[d.index(i)], nil
+}
+
+func (d *Deque[T]) Len() int {
+	return d.count
+}
+
+// Returns the size of the buffer.
+func (d *Deque[T]) Cap() int {
+	return len(d.data)
+}
+
+func (d *Deque[T]) Empty() bool {
+	return d.count == 0
+}
+
+// Iterates over the elements from front to back.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.count; i++ {
+			if !yield(i, d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Iterates over the elements from back to front.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.count - 1; i >= 0; i-- {
+			if !yield(i, d.data[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
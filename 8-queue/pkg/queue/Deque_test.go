@@ -0,0 +1,225 @@
+package queue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func expectDeque(t *testing.T, d *Deque[int], want []int) {
+	t.Helper()
+
+	if d.Len() != len(want) || d.Empty() != (len(want) == 0) {
+		t.Fatalf("Len: got %d, want %d", d.Len(), len(want))
+	}
+
+	got := []int{}
+
+	for i, v := range d.All() {
+		if i != len(got) {
+			t.Fatalf("All: got index %d, want %d", i, len(got))
+		}
+
+		got = append(got, v)
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("All: got %v, want %v", got, want)
+	}
+
+	got = got[:0]
+
+	for _, v := range d.Backward() {
+		got = append(got, v)
+	}
+
+	slices.Reverse(got)
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("Backward: got reversed %v, want %v", got, want)
+	}
+
+	for i, w := range want {
+		if v, err := d.At(i); err != nil || v != w {
+			t.Fatalf("At(%d): got %d, %v, want %d", i, v, err, w)
+		}
+	}
+
+	if _, err := d.At(len(want)); err != ErrIndex {
+		t.Fatalf("At(%d): got %v, want ErrIndex", len(want), err)
+	}
+}
+
+func TestDequeWrapFront(t *testing.T) {
+	d := NewDeque[int](4)
+
+	// Pushing to the front of an empty buffer wraps to the last slot
+	d.PushFront(1)
+
+	if d.front != 3 {
+		t.Fatalf("front at %d, want 3", d.front)
+	}
+
+	d.PushBack(2)
+	d.PushFront(0)
+	d.PushBack(3)
+	expectDeque(t, d, []int{0, 1, 2, 3})
+
+	if len(d.data) != 4 {
+		t.Fatalf("buffer grew to %d before it was full", len(d.data))
+	}
+
+	// Growing while wrapped has to unwrap the elements in order
+	d.PushFront(-1)
+	expectDeque(t, d, []int{-1, 0, 1, 2, 3})
+
+	if len(d.data) != 8 {
+		t.Fatalf("buffer has size %d, want 8", len(d.data))
+	}
+}
+
+func TestDequeWrapBack(t *testing.T) {
+	d := NewDeque[int](4)
+
+	for i := 0; i < 3; i++ {
+		d.PushBack(i)
+	}
+
+	d.PopFront()
+	d.PopFront()
+
+	// The back wraps around to index 0 and 1
+	d.PushBack(3)
+	d.PushBack(4)
+	d.PushBack(5)
+	expectDeque(t, d, []int{2, 3, 4, 5})
+
+	if v, _ := d.PeekBack(); v != 5 {
+		t.Fatalf("PeekBack: got %d, want 5", v)
+	}
+
+	if v, _ := d.PeekFront(); v != 2 {
+		t.Fatalf("PeekFront: got %d, want 2", v)
+	}
+
+	for _, w := range []int{5, 4, 3, 2} {
+		if v, err := d.PopBack(); err != nil || v != w {
+			t.Fatalf("PopBack: got %d, %v, want %d", v, err, w)
+		}
+	}
+
+	expectDeque(t, d, []int{})
+}
+
+func TestDequeShrinkWrapped(t *testing.T) {
+	d := NewDeque[int](2)
+
+	for i := 0; i < 32; i++ {
+		d.PushFront(i)
+	}
+
+	// Leave a few elements straddling the end of the buffer
+	for i := 0; i < 26; i++ {
+		d.PopBack()
+	}
+
+	d.PushBack(-1)
+	d.PushFront(32)
+	expectDeque(t, d, []int{32, 31, 30, 29, 28, 27, 26, -1})
+}
+
+func TestDequeEmpty(t *testing.T) {
+	d := NewDeque[int](0)
+
+	if _, err := d.PopFront(); err == nil {
+		t.Error("PopFront on an empty deque didn't fail")
+	}
+
+	if _, err := d.PopBack(); err == nil {
+		t.Error("PopBack on an empty deque didn't fail")
+	}
+
+	if _, err := d.PeekFront(); err == nil {
+		t.Error("PeekFront on an empty deque didn't fail")
+	}
+
+	if _, err := d.PeekBack(); err == nil {
+		t.Error("PeekBack on an empty deque didn't fail")
+	}
+
+	// Zero values are stored like any other value
+	d.PushBack(0)
+
+	if d.Empty() {
+		t.Error("deque holding a zero value is empty")
+	}
+}
+
+func TestDequeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := NewDeque[int](1)
+	want := []int{}
+
+	for i := 0; i < 5000; i++ {
+		switch rng.Intn(4) {
+		case 0:
+			d.PushFront(i)
+			want = slices.Insert(want, 0, i)
+		case 1:
+			d.PushBack(i)
+			want = append(want, i)
+		case 2:
+			v, err := d.PopFront()
+
+			if len(want) == 0 {
+				if err == nil {
+					t.Fatal("PopFront on an empty deque didn't fail")
+				}
+			} else if err != nil || v != want[0] {
+				t.Fatalf("PopFront: got %d, %v, want %d", v, err, want[0])
+			} else {
+				want = want[1:]
+			}
+		case 3:
+			v, err := d.PopBack()
+
+			if len(want) == 0 {
+				if err == nil {
+					t.Fatal("PopBack on an empty deque didn't fail")
+				}
+			} else if err != nil || v != want[len(want)-1] {
+				t.Fatalf("PopBack: got %d, %v, want %d", v, err, want[len(want)-1])
+			} else {
+				want = want[:len(want)-1]
+			}
+		}
+
+		if i%25 == 0 {
+			expectDeque(t, d, want)
+		}
+	}
+}
+
+func TestDequeReallocateClamp(t *testing.T) {
+	d := NewDeque[int](4)
+
+	for i := 0; i < 3; i++ {
+		d.PushFront(i)
+	}
+
+	// Too small to hold the elements, so the buffer only shrinks to fit
+	d.Reallocate(1)
+	expectDeque(t, d, []int{2, 1, 0})
+
+	if d.Cap() != 3 {
+		t.Fatalf("Cap: got %d, want 3", d.Cap())
+	}
+
+	for !d.Empty() {
+		d.PopBack()
+	}
+
+	d.Reallocate(0)
+	d.PushBack(5)
+	expectDeque(t, d, []int{5})
+}
@@ -0,0 +1,130 @@
+package queue
+
+import (
+	"sync/atomic"
+)
+
+type mpmcCell[T any] struct {
+	// Equals the position when the cell is free to write at that position,
+	// and the position + 1 once it holds the value written there.
+	seq atomic.Uint64
+	val T
+}
+
+// Lock-free bounded queue for any number of producers and consumers, after
+// Dmitry Vyukov's design. Each cell carries a sequence number that tells a
+// producer or consumer whether it's their turn, so claiming a slot is a
+// single CAS on the tail or head. Enqueue and Dequeue fail with ErrFull or
+// ErrEmpty instead of blocking.
+type MPMCQueue[T any] struct {
+	_    [cacheLine]byte
+	head atomic.Uint64
+	_    [cacheLine - 8]byte
+	tail atomic.Uint64
+	_    [cacheLine - 8]byte
+	data []mpmcCell[T]
+	mask uint64
+}
+
+// Creates a queue that holds at least size elements. The capacity is rounded
+// up to a power of two.
+func NewMPMCQueue[T any](size int) *MPMCQueue[T] {
+	n := ringSize(size)
+	q := &MPMCQueue[T]{
+		data: make([]mpmcCell[T], n),
+		mask: uint64(n - 1),
+	}
+
+	for i := range q.data {
+		q.data[i].seq.Store(uint64(i))
+	}
+
+	return q
+}
+
+func (q *MPMCQueue[T]) Enqueue(val T) error {
+	pos := q.tail.Load()
+
+	for {
+		cell := &q.data[pos&q.mask]
+		diff := int64(cell.seq.Load() - pos)
+
+		switch {
+		case diff == 0:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				cell.val = val
+				cell.seq.Store(pos + 1)
+
+				return nil
+			}
+
+			pos = q.tail.Load()
+		case diff < 0:
+			// The cell still holds the value from the previous lap
+			return ErrFull
+		default:
+			// Another producer claimed pos first
+			pos = q.tail.Load()
+		}
+	}
+}
+
+func (q *MPMCQueue[T]) Dequeue() (T, error) {
+	var zero T
+
+	pos := q.head.Load()
+
+	for {
+		cell := &q.data[pos&q.mask]
+		diff := int64(cell.seq.Load() - (pos + 1))
+
+		switch {
+		case diff == 0:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				val := cell.val
+				cell.val = zero
+				cell.seq.Store(pos + q.mask + 1)
+
+				return val, nil
+			}
+
+			pos = q.head.Load()
+		case diff < 0:
+			// Nothing has been written at pos yet
+			return zero, ErrEmpty
+		default:
+			// Another consumer claimed pos first
+			pos = q.head.Load()
+		}
+	}
+}
+
+// Returns the front element without removing it. Must not run concurrently
+// with Dequeue, since the element may be taken and overwritten while it's
+// being read.
+func (q *MPMCQueue[T]) Peek() (T, error) {
+	pos := q.head.Load()
+	cell := &q.data[pos&q.mask]
+
+	if cell.seq.Load() != pos+1 {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return cell.val, nil
+}
+
+// Returns the number of queued elements, including ones whose slots have been
+// claimed but not yet written or read.
+func (q *MPMCQueue[T]) Len() int {
+	head := q.head.Load()
+	return int(q.tail.Load() - head)
+}
+
+func (q *MPMCQueue[T]) Cap() int {
+	return len(q.data)
+}
+
+func (q *MPMCQueue[T]) Empty() bool {
+	return q.Len() == 0
+}
@@ -1,76 +1,44 @@
 package queue
 
-import (
-	"errors"
-)
-
-type QueueDynamic[T comparable] struct {
-	data  []T
-	front int
-	back  int
-	count int
+// Growable circular buffer queue. The buffer doubles when full and halves
+// when less than a quarter is used, which the Deque already does.
+type QueueDynamic[T any] struct {
+	data *Deque[T]
 }
 
-func NewQueueDynamic[T comparable](size int) *QueueDynamic[T] {
+func NewQueueDynamic[T any](size int) *QueueDynamic[T] {
 	return &QueueDynamic[T]{
-		data:  make([]T, size),
-		back:  0,
-		front: 0,
+		data: NewDeque[T](size),
 	}
 }
 
-func (q *QueueDynamic[T]) nextIndexWrapped(idx int) int {
-	return mod((idx + 1), cap(q.data))
-}
-
 func (q *QueueDynamic[T]) Reallocate(newSize int) {
-	newData := make([]T, newSize)
-
-	if newSize < cap(q.data) {
-		copy(newData, q.data[q.front:q.front+q.count])
-	} else {
-		endDist := cap(q.data) - q.front
-		copy(newData[0:endDist], q.data[q.front:])
-		copy(newData[endDist:], q.data[:q.front])
-	}
-
-	q.front = 0
-	q.back = q.count
-	q.data = newData
+	q.data.Reallocate(newSize)
 }
 
 func (q *QueueDynamic[T]) Enqueue(val T) error {
-	if q.back == q.front && !q.Empty() {
-		q.Reallocate(cap(q.data) * 2)
-	}
-
-	q.data[q.back] = val
-	q.back = q.nextIndexWrapped(q.back)
-	q.count++
-
+	q.data.PushBack(val)
 	return nil
 }
 
 func (q *QueueDynamic[T]) Dequeue() (T, error) {
-	var zero T
-
-	if q.Empty() {
-		return zero, errors.New("queue is empty")
-	}
+	return q.data.PopFront()
+}
 
-	val := q.data[q.front]
-	q.data[q.front] = zero
-	q.front = q.nextIndexWrapped(q.front)
-	q.count--
+// Returns the front element without removing it.
+func (q *QueueDynamic[T]) Peek() (T, error) {
+	return q.data.PeekFront()
+}
 
-	if q.count < cap(q.data)/4 {
-		q.Reallocate(cap(q.data) / 2)
-	}
+func (q *QueueDynamic[T]) Len() int {
+	return q.data.Len()
+}
 
-	return val, nil
+// Returns the size of the buffer, which changes as the queue grows and shrinks.
+func (q *QueueDynamic[T]) Cap() int {
+	return q.data.Cap()
 }
 
 func (q *QueueDynamic[T]) Empty() bool {
-	var zero T
-	return q.back == q.front && q.data[q.front] == zero
+	return q.data.Empty()
 }
@@ -1,9 +1,7 @@
 package queue
 
 import (
-	"errors"
-
-	"github.com/phanty133/id1021/8-queue/pkg/llist"
+	"github.com/phanty133/id1021/containers/llist"
 )
 
 type QueueLL[T comparable] struct {
@@ -24,7 +22,7 @@ func (q *QueueLL[T]) Enqueue(val T) error {
 func (q *QueueLL[T]) Dequeue() (T, error) {
 	if q.Empty() {
 		var zero T
-		return zero, errors.New("queue is empty")
+		return zero, ErrEmpty
 	}
 
 	val := q.data.First().Head
@@ -33,6 +31,20 @@ func (q *QueueLL[T]) Dequeue() (T, error) {
 	return val, nil
 }
 
+// Returns the front element without removing it.
+func (q *QueueLL[T]) Peek() (T, error) {
+	if q.Empty() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return q.data.First().Head, nil
+}
+
+func (q *QueueLL[T]) Len() int {
+	return q.data.Length()
+}
+
 func (q *QueueLL[T]) Empty() bool {
 	return q.data.First() == nil
 }
@@ -1,19 +1,14 @@
 package queue
 
-import (
-	"errors"
-)
-
-type QueueStatic[T comparable] struct {
+type QueueStatic[T any] struct {
 	data  []T
 	front int
-	back  int
+	count int
 }
 
-func NewQueueStatic[T comparable](size int) *QueueStatic[T] {
+func NewQueueStatic[T any](size int) *QueueStatic[T] {
 	return &QueueStatic[T]{
 		data:  make([]T, size),
-		back:  0,
 		front: 0,
 	}
 }
@@ -23,12 +18,12 @@ func (q *QueueStatic[T]) nextIndexWrapped(idx int) int {
 }
 
 func (q *QueueStatic[T]) Enqueue(val T) error {
-	if q.back == q.front && !q.Empty() {
-		return errors.New("queue is full")
+	if q.count == cap(q.data) {
+		return ErrFull
 	}
 
-	q.data[q.back] = val
-	q.back = q.nextIndexWrapped(q.back)
+	q.data[mod(q.front+q.count, cap(q.data))] = val
+	q.count++
 
 	return nil
 }
@@ -37,17 +32,35 @@ func (q *QueueStatic[T]) Dequeue() (T, error) {
 	var zero T
 
 	if q.Empty() {
-		return zero, errors.New("queue is empty")
+		return zero, ErrEmpty
 	}
 
 	val := q.data[q.front]
 	q.data[q.front] = zero
 	q.front = q.nextIndexWrapped(q.front)
+	q.count--
 
 	return val, nil
 }
 
+// Returns the front element without removing it.
+func (q *QueueStatic[T]) Peek() (T, error) {
+	if q.Empty() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return q.data[q.front], nil
+}
+
+func (q *QueueStatic[T]) Len() int {
+	return q.count
+}
+
+func (q *QueueStatic[T]) Cap() int {
+	return cap(q.data)
+}
+
 func (q *QueueStatic[T]) Empty() bool {
-	var zero T
-	return q.back == q.front && q.data[q.front] == zero
+	return q.count == 0
 }
@@ -0,0 +1,118 @@
+package queue
+
+import (
+	"sync/atomic"
+)
+
+// Assumed size of a CPU cache line. Indices written by different goroutines
+// are kept on separate lines so they don't invalidate each other's cache.
+const cacheLine = 64
+
+// Rounds size up to a power of two, and to at least 2.
+func ringSize(size int) int {
+	n := 2
+
+	for n < size {
+		n *= 2
+	}
+
+	return n
+}
+
+// Wait-free ring buffer for exactly one producer and one consumer goroutine.
+// Enqueue and Dequeue never block; they fail with ErrFull or ErrEmpty instead.
+type SPSCQueue[T any] struct {
+	_ [cacheLine]byte
+	// Next slot to read, written by the consumer
+	head atomic.Uint64
+	_    [cacheLine - 8]byte
+	// Next slot to write, written by the producer
+	tail atomic.Uint64
+	_    [cacheLine - 8]byte
+	// Last head seen by the producer, so it only loads head when it looks full
+	headCache uint64
+	_         [cacheLine - 8]byte
+	// Last tail seen by the consumer, so it only loads tail when it looks empty
+	tailCache uint64
+	_         [cacheLine - 8]byte
+	data      []T
+	mask      uint64
+}
+
+// Creates a queue that holds at least size elements. The capacity is rounded
+// up to a power of two.
+func NewSPSCQueue[T any](size int) *SPSCQueue[T] {
+	n := ringSize(size)
+
+	return &SPSCQueue[T]{
+		data: make([]T, n),
+		mask: uint64(n - 1),
+	}
+}
+
+// Must only be called from the producer.
+func (q *SPSCQueue[T]) Enqueue(val T) error {
+	tail := q.tail.Load()
+
+	if tail-q.headCache == uint64(len(q.data)) {
+		q.headCache = q.head.Load()
+
+		if tail-q.headCache == uint64(len(q.data)) {
+			return ErrFull
+		}
+	}
+
+	q.data[tail&q.mask] = val
+	q.tail.Store(tail + 1)
+
+	return nil
+}
+
+// Must only be called from the consumer.
+func (q *SPSCQueue[T]) Dequeue() (T, error) {
+	var zero T
+
+	head := q.head.Load()
+
+	if head == q.tailCache {
+		q.tailCache = q.tail.Load()
+
+		if head == q.tailCache {
+			return zero, ErrEmpty
+		}
+	}
+
+	val := q.data[head&q.mask]
+	q.data[head&q.mask] = zero
+	q.head.Store(head + 1)
+
+	return val, nil
+}
+
+// Returns the front element without removing it. Must only be called from
+// the consumer.
+func (q *SPSCQueue[T]) Peek() (T, error) {
+	head := q.head.Load()
+
+	if head == q.tail.Load() {
+		var zero T
+		return zero, ErrEmpty
+	}
+
+	return q.data[head&q.mask], nil
+}
+
+// Returns the number of queued elements, which may be stale by the time it's
+// used if the other side is running.
+func (q *SPSCQueue[T]) Len() int {
+	head := q.head.Load()
+	return int(q.tail.Load() - head)
+}
+
+func (q *SPSCQueue[T]) Cap() int {
+	return len(q.data)
+}
+
+func (q *SPSCQueue[T]) Empty() bool {
+	return q.Len() == 0
+}
@@ -0,0 +1,166 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// Run with -race; the stress tests are what lets the race detector check the
+// hand-offs between goroutines.
+
+func TestRingSize(t *testing.T) {
+	for _, c := range [][2]int{{0, 2}, {1, 2}, {2, 2}, {3, 4}, {64, 64}, {65, 128}} {
+		if got := NewSPSCQueue[int](c[0]).Cap(); got != c[1] {
+			t.Errorf("SPSC Cap for size %d: got %d, want %d", c[0], got, c[1])
+		}
+
+		if got := NewMPMCQueue[int](c[0]).Cap(); got != c[1] {
+			t.Errorf("MPMC Cap for size %d: got %d, want %d", c[0], got, c[1])
+		}
+	}
+}
+
+func TestLockFreeFull(t *testing.T) {
+	for _, q := range []Queue[int]{NewSPSCQueue[int](4), NewMPMCQueue[int](4)} {
+		// Go around the ring a few times, filling it up each lap
+		for lap := 0; lap < 3; lap++ {
+			for i := 0; i < 4; i++ {
+				if err := q.Enqueue(i); err != nil {
+					t.Fatalf("%T Enqueue: %v", q, err)
+				}
+			}
+
+			if err := q.Enqueue(4); err != ErrFull {
+				t.Fatalf("%T Enqueue on a full queue: got %v, want ErrFull", q, err)
+			}
+
+			for i := 0; i < 4; i++ {
+				if v, err := q.Dequeue(); err != nil || v != i {
+					t.Fatalf("%T Dequeue: got %d, %v, want %d", q, v, err, i)
+				}
+			}
+
+			if _, err := q.Dequeue(); err != ErrEmpty {
+				t.Fatalf("%T Dequeue on an empty queue: got %v, want ErrEmpty", q, err)
+			}
+		}
+	}
+}
+
+func TestSPSCStress(t *testing.T) {
+	const n = 200000
+
+	q := NewSPSCQueue[int](8)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < n; i++ {
+			for q.Enqueue(i) != nil {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	for want := 0; want < n; {
+		v, err := q.Dequeue()
+
+		if err != nil {
+			runtime.Gosched()
+			continue
+		}
+
+		if v != want {
+			t.Fatalf("Dequeue: got %d, want %d", v, want)
+		}
+
+		want++
+	}
+
+	<-done
+
+	if !q.Empty() {
+		t.Fatalf("queue has %d elements left", q.Len())
+	}
+}
+
+func TestMPMCStress(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 20000
+
+	q := NewMPMCQueue[int](16)
+	counts := make([][]int, consumers)
+
+	var wg sync.WaitGroup
+
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < perProducer; i++ {
+				for q.Enqueue(p*perProducer+i) != nil {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	var taken sync.WaitGroup
+	remaining := make(chan struct{}, producers*perProducer)
+
+	for i := 0; i < producers*perProducer; i++ {
+		remaining <- struct{}{}
+	}
+
+	close(remaining)
+
+	for c := 0; c < consumers; c++ {
+		taken.Add(1)
+		counts[c] = make([]int, producers*perProducer)
+
+		go func() {
+			defer taken.Done()
+
+			last := make([]int, producers)
+
+			for i := range last {
+				last[i] = -1
+			}
+
+			// Each token is one value left to dequeue
+			for range remaining {
+				v, err := q.Dequeue()
+
+				for err != nil {
+					runtime.Gosched()
+					v, err = q.Dequeue()
+				}
+
+				if v%perProducer <= last[v/perProducer] {
+					t.Errorf("got %d after %d from the same producer", v, last[v/perProducer])
+				}
+
+				last[v/perProducer] = v % perProducer
+				counts[c][v]++
+			}
+		}()
+	}
+
+	wg.Wait()
+	taken.Wait()
+
+	for v := 0; v < producers*perProducer; v++ {
+		var n int
+
+		for c := range counts {
+			n += counts[c][v]
+		}
+
+		if n != 1 {
+			t.Fatalf("value %d dequeued %d times", v, n)
+		}
+	}
+}
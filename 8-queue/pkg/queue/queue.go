@@ -1,7 +1,21 @@
 package queue
 
-type Queue[T comparable] interface {
+import "errors"
+
+// Returned by every Queue implementation, so callers can check them with
+// errors.Is whichever queue they use.
+var (
+	ErrEmpty  = errors.New("queue is empty")
+	ErrFull   = errors.New("queue is full")
+	ErrClosed = errors.New("queue is closed")
+	ErrIndex  = errors.New("index out of range")
+)
+
+// Len counts the queued elements. Bounded queues additionally have a Cap.
+type Queue[T any] interface {
 	Enqueue(val T) error
 	Dequeue() (T, error)
+	Peek() (T, error)
+	Len() int
 	Empty() bool
 }
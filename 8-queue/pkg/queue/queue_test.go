@@ -0,0 +1,169 @@
+package queue
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+var constructors = []struct {
+	Name string
+	New  func(size int) Queue[int]
+}{
+	{"static", func(size int) Queue[int] { return NewQueueStatic[int](size) }},
+	{"dynamic", func(size int) Queue[int] { return NewQueueDynamic[int](1) }},
+	{"ll", func(size int) Queue[int] { return NewQueueLL[int]() }},
+	{"spsc", func(size int) Queue[int] { return NewSPSCQueue[int](size) }},
+	{"mpmc", func(size int) Queue[int] { return NewMPMCQueue[int](size) }},
+}
+
+// Runs the tests every Queue must pass. newQueue must return an empty queue
+// that holds at least size elements.
+func runSuite[T any](t *testing.T, newQueue func(size int) Queue[T], vals []T, eq func(a, b T) bool) {
+	t.Run("Empty", func(t *testing.T) {
+		q := newQueue(len(vals))
+
+		if !q.Empty() || q.Len() != 0 {
+			t.Fatalf("new queue has %d elements", q.Len())
+		}
+
+		if _, err := q.Dequeue(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("Dequeue on an empty queue: got %v, want ErrEmpty", err)
+		}
+
+		if _, err := q.Peek(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("Peek on an empty queue: got %v, want ErrEmpty", err)
+		}
+	})
+
+	t.Run("FIFO", func(t *testing.T) {
+		q := newQueue(len(vals))
+
+		for i, v := range vals {
+			if err := q.Enqueue(v); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+
+			if q.Empty() || q.Len() != i+1 {
+				t.Fatalf("Len after %d enqueues: got %d", i+1, q.Len())
+			}
+		}
+
+		for i, want := range vals {
+			if v, err := q.Peek(); err != nil || !eq(v, want) {
+				t.Fatalf("Peek: got %v, %v, want %v", v, err, want)
+			}
+
+			if v, err := q.Dequeue(); err != nil || !eq(v, want) {
+				t.Fatalf("Dequeue: got %v, %v, want %v", v, err, want)
+			}
+
+			if q.Len() != len(vals)-i-1 {
+				t.Fatalf("Len after %d dequeues: got %d", i+1, q.Len())
+			}
+		}
+
+		if !q.Empty() {
+			t.Fatal("queue isn't empty after dequeuing everything")
+		}
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		q := newQueue(len(vals))
+
+		// Keep the queue half full so the ring buffers wrap several times
+		for i := 0; i < len(vals)/2; i++ {
+			q.Enqueue(vals[i])
+		}
+
+		for i := 0; i < 3*len(vals); i++ {
+			want := vals[i%len(vals)]
+
+			if v, err := q.Dequeue(); err != nil || !eq(v, want) {
+				t.Fatalf("Dequeue %d: got %v, %v, want %v", i, v, err, want)
+			}
+
+			if err := q.Enqueue(vals[(i+len(vals)/2)%len(vals)]); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+		}
+
+		if q.Len() != len(vals)/2 {
+			t.Fatalf("Len: got %d, want %d", q.Len(), len(vals)/2)
+		}
+	})
+}
+
+func TestQueues(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ints := make([]int, 50)
+
+	for i := range ints {
+		ints[i] = rng.Intn(100)
+	}
+
+	for _, c := range constructors {
+		t.Run(c.Name, func(t *testing.T) {
+			runSuite(t, c.New, ints, func(a, b int) bool { return a == b })
+		})
+	}
+}
+
+func TestZeroValues(t *testing.T) {
+	zeros := make([]int, 10)
+
+	for _, c := range constructors {
+		t.Run(c.Name, func(t *testing.T) {
+			runSuite(t, c.New, zeros, func(a, b int) bool { return a == b })
+		})
+	}
+
+	// Ring buffers take any type, including ones that aren't comparable
+	one := new(int)
+	lists := [][]int{nil, {1}, nil, {}, {2, 3}, nil}
+	sliceEq := func(a, b []int) bool {
+		return (a == nil) == (b == nil) && len(a) == len(b) && (len(a) == 0 || a[0] == b[0])
+	}
+
+	runSuite(t, func(size int) Queue[[]int] { return NewQueueStatic[[]int](size) }, lists, sliceEq)
+	runSuite(t, func(size int) Queue[[]int] { return NewQueueDynamic[[]int](1) }, lists, sliceEq)
+	runSuite(t, func(size int) Queue[*int] { return NewQueueLL[*int]() }, []*int{nil, one, nil}, func(a, b *int) bool { return a == b })
+}
+
+func TestStaticFull(t *testing.T) {
+	q := NewQueueStatic[int](3)
+
+	for i := 0; i < 3; i++ {
+		if err := q.Enqueue(0); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	if err := q.Enqueue(0); !errors.Is(err, ErrFull) {
+		t.Fatalf("Enqueue on a full queue: got %v, want ErrFull", err)
+	}
+
+	if q.Len() != 3 || q.Cap() != 3 {
+		t.Fatalf("got Len %d, Cap %d, want 3, 3", q.Len(), q.Cap())
+	}
+}
+
+func TestDynamicCap(t *testing.T) {
+	q := NewQueueDynamic[int](2)
+
+	for i := 0; i < 9; i++ {
+		q.Enqueue(0)
+	}
+
+	if q.Cap() != 16 {
+		t.Fatalf("Cap after 9 enqueues: got %d, want 16", q.Cap())
+	}
+
+	for i := 0; i < 9; i++ {
+		q.Dequeue()
+	}
+
+	if q.Cap() != 2 {
+		t.Fatalf("Cap after emptying: got %d, want 2", q.Cap())
+	}
+}
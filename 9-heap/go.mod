@@ -1,3 +1,7 @@
 module github.com/phanty133/id1021/9-heap
 
-go 1.21
+go 1.23
+
+require github.com/phanty133/id1021/containers v0.0.0
+
+replace github.com/phanty133/id1021/containers => ../containers
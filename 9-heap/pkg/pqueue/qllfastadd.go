@@ -2,7 +2,7 @@ package pqueue
 
 import (
 	"errors"
-	"github.com/phanty133/id1021/9-heap/pkg/llist"
+	"github.com/phanty133/id1021/containers/llist"
 )
 
 type PQueueLLFastAdd[T comparable] struct {
@@ -47,11 +47,7 @@ func (h *PQueueLLFastAdd[T]) Remove() (T, error) {
 		node = node.Next()
 	}
 
-	if minPrevNode == nil {
-		h.list.SetFirst(min.Next())
-	} else {
-		minPrevNode.SetNext(min.Next())
-	}
+	h.list.UnlinkAfter(minPrevNode)
 
 	return min.Head.Value, nil
 }
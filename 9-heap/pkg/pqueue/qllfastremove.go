@@ -2,7 +2,7 @@ package pqueue
 
 import (
 	"errors"
-	"github.com/phanty133/id1021/9-heap/pkg/llist"
+	"github.com/phanty133/id1021/containers/llist"
 )
 
 type PQueueLLFastRemove[T comparable] struct {
@@ -36,15 +36,7 @@ func (h *PQueueLLFastRemove[T]) Add(value T, priority int) {
 		node = node.Next()
 	}
 
-	newNode := &llist.LinkedListItem[PriorityQueueItem[T]]{Head: newItem}
-
-	if prevNode == nil {
-		newNode.SetNext(h.list.First())
-		h.list.SetFirst(newNode)
-	} else {
-		newNode.SetNext(prevNode.Next())
-		prevNode.SetNext(newNode)
-	}
+	h.list.InsertAfter(prevNode, newItem)
 }
 
 func (h *PQueueLLFastRemove[T]) Remove() (T, error) {
@@ -53,10 +45,8 @@ func (h *PQueueLLFastRemove[T]) Remove() (T, error) {
 		return zero, errors.New("heap empty")
 	}
 
-	f := h.list.First()
-	val := f.Head.Value
-	h.list.SetFirst(f.Next())
-	return val, nil
+	f := h.list.UnlinkAfter(nil)
+	return f.Head.Value, nil
 }
 
 func (h *PQueueLLFastRemove[T]) Empty() bool {
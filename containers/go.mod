@@ -0,0 +1,3 @@
+module github.com/phanty133/id1021/containers
+
+go 1.23
@@ -0,0 +1,153 @@
+package llist
+
+// Reverses the list in place.
+func (l *LinkedList[T]) Reverse() {
+	var prev *LinkedListItem[T]
+	item := l.first
+
+	for item != nil {
+		next := item.next
+		item.next = prev
+		prev = item
+		item = next
+	}
+
+	l.first, l.last = l.last, l.first
+}
+
+// Cuts the list after its first n items and returns the rest as a new list.
+func (l *LinkedList[T]) SplitAt(n int) *LinkedList[T] {
+	if n <= 0 {
+		rest := &LinkedList[T]{l.first, l.last, l.length}
+		*l = LinkedList[T]{}
+		return rest
+	}
+
+	if n >= l.length {
+		return New[T]()
+	}
+
+	prev := l.first
+
+	for i := 1; i < n; i++ {
+		prev = prev.next
+	}
+
+	return l.splitAfter(prev, n)
+}
+
+// Cuts the list before the first item whose value satisfies pred and returns
+// that item and everything after it as a new list.
+func (l *LinkedList[T]) SplitFunc(pred func(T) bool) *LinkedList[T] {
+	var prev *LinkedListItem[T]
+	n := 0
+
+	for item := l.first; item != nil; item = item.next {
+		if pred(item.Head) {
+			if prev == nil {
+				return l.SplitAt(0)
+			}
+
+			return l.splitAfter(prev, n)
+		}
+
+		prev = item
+		n++
+	}
+
+	return New[T]()
+}
+
+// Splits after prev, which is the n-th item (counting from 1).
+func (l *LinkedList[T]) splitAfter(prev *LinkedListItem[T], n int) *LinkedList[T] {
+	rest := &LinkedList[T]{prev.next, l.last, l.length - n}
+
+	prev.next = nil
+	l.last = prev
+	l.length = n
+
+	return rest
+}
+
+// Moves all items of other to the end of the list in O(1), leaving other
+// empty.
+func (l *LinkedList[T]) Splice(other *LinkedList[T]) {
+	if other == l || other.first == nil {
+		return
+	}
+
+	if l.last == nil {
+		l.first = other.first
+	} else {
+		l.last.next = other.first
+	}
+
+	l.last = other.last
+	l.length += other.length
+
+	*other = LinkedList[T]{}
+}
+
+// Removes consecutive items with equal values, keeping the first of each run.
+func (l *LinkedList[T]) Compact() {
+	for item := l.first; item != nil; item = item.next {
+		for item.next != nil && item.next.Head == item.Head {
+			l.UnlinkAfter(item)
+		}
+	}
+}
+
+// Returns the first item of a cycle in the list, or nil if the list ends. Uses
+// Floyd's tortoise and hare, so it needs no extra memory. A cycle can only
+// appear if an item that is already linked is inserted again.
+func (l *LinkedList[T]) FindCycle() *LinkedListItem[T] {
+	slow, fast := l.first, l.first
+
+	for fast != nil && fast.next != nil {
+		slow = slow.next
+		fast = fast.next.next
+
+		if slow == fast {
+			// The distance from the start to the cycle equals the distance
+			// from the meeting point to the cycle, modulo the cycle length
+			slow = l.first
+
+			for slow != fast {
+				slow = slow.next
+				fast = fast.next
+			}
+
+			return slow
+		}
+	}
+
+	return nil
+}
+
+func (l *LinkedList[T]) HasCycle() bool {
+	return l.FindCycle() != nil
+}
+
+// Returns a new list with the values that satisfy pred.
+func (l *LinkedList[T]) Filter(pred func(T) bool) *LinkedList[T] {
+	res := New[T]()
+
+	for item := l.first; item != nil; item = item.next {
+		if pred(item.Head) {
+			res.Append(item.Head)
+		}
+	}
+
+	return res
+}
+
+// Returns a new list with f applied to each value of l.
+func Map[T, U comparable](l *LinkedList[T], f func(T) U) *LinkedList[U] {
+	res := New[U]()
+
+	for item := l.first; item != nil; item = item.next {
+		res.Append(f(item.Head))
+	}
+
+	return res
+}
@@ -0,0 +1,173 @@
+package llist
+
+import (
+	"slices"
+	"testing"
+)
+
+func fromSlice(vals ...int) *LinkedList[int] {
+	l := New[int]()
+
+	for _, v := range vals {
+		l.Append(v)
+	}
+
+	return l
+}
+
+// Checks the values, the cached length and that the tail is the last item.
+func expect(t *testing.T, l *LinkedList[int], want ...int) {
+	t.Helper()
+
+	got := []int{}
+	var last *LinkedListItem[int]
+
+	for item := range l.Items() {
+		got = append(got, item.Head)
+		last = item
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if l.Length() != len(want) {
+		t.Fatalf("length: got %d, want %d", l.Length(), len(want))
+	}
+
+	if l.Last() != last {
+		t.Fatal("tail isn't the last item")
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for n := 0; n < 4; n++ {
+		vals := make([]int, n)
+
+		for i := range vals {
+			vals[i] = i
+		}
+
+		l := fromSlice(vals...)
+		l.Reverse()
+		slices.Reverse(vals)
+		expect(t, l, vals...)
+
+		// The list must still be usable at both ends
+		l.Append(10)
+		l.Add(-1)
+		expect(t, l, append(append([]int{-1}, vals...), 10)...)
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	cases := []struct {
+		n           int
+		front, back []int
+	}{
+		{-1, []int{}, []int{1, 2, 3}},
+		{0, []int{}, []int{1, 2, 3}},
+		{1, []int{1}, []int{2, 3}},
+		{2, []int{1, 2}, []int{3}},
+		{3, []int{1, 2, 3}, []int{}},
+		{4, []int{1, 2, 3}, []int{}},
+	}
+
+	for _, c := range cases {
+		l := fromSlice(1, 2, 3)
+		rest := l.SplitAt(c.n)
+		expect(t, l, c.front...)
+		expect(t, rest, c.back...)
+	}
+
+	expect(t, New[int]().SplitAt(0))
+}
+
+func TestSplitFunc(t *testing.T) {
+	l := fromSlice(1, 2, 3, 4)
+	rest := l.SplitFunc(func(v int) bool { return v > 2 })
+	expect(t, l, 1, 2)
+	expect(t, rest, 3, 4)
+
+	rest = l.SplitFunc(func(v int) bool { return v > 10 })
+	expect(t, l, 1, 2)
+	expect(t, rest)
+
+	rest = l.SplitFunc(func(v int) bool { return v == 1 })
+	expect(t, l)
+	expect(t, rest, 1, 2)
+}
+
+func TestSplice(t *testing.T) {
+	a := fromSlice(1, 2)
+	b := fromSlice(3, 4)
+	a.Splice(b)
+	expect(t, a, 1, 2, 3, 4)
+	expect(t, b)
+
+	a.Splice(b)
+	a.Splice(a)
+	expect(t, a, 1, 2, 3, 4)
+
+	b.Splice(a)
+	expect(t, b, 1, 2, 3, 4)
+	expect(t, a)
+
+	b.Append(5)
+	expect(t, b, 1, 2, 3, 4, 5)
+}
+
+func TestCompact(t *testing.T) {
+	l := fromSlice(1, 1, 2, 3, 3, 3, 1, 4, 4)
+	l.Compact()
+	expect(t, l, 1, 2, 3, 1, 4)
+
+	l = fromSlice(5, 5, 5)
+	l.Compact()
+	expect(t, l, 5)
+
+	l = New[int]()
+	l.Compact()
+	expect(t, l)
+}
+
+func TestFindCycle(t *testing.T) {
+	if fromSlice().HasCycle() || fromSlice(1).HasCycle() || fromSlice(1, 2, 3).HasCycle() {
+		t.Fatal("cycle found in acyclic list")
+	}
+
+	for n := 1; n <= 6; n++ {
+		for start := 0; start < n; start++ {
+			l := New[int]()
+			items := make([]*LinkedListItem[int], n)
+
+			for i := range items {
+				items[i] = l.Append(i)
+			}
+
+			l.Last().next = items[start]
+
+			if got := l.FindCycle(); got != items[start] {
+				t.Errorf("n=%d: cycle start got %v, want item %d", n, got, start)
+			}
+		}
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	l := fromSlice(1, 2, 3, 4)
+	even := l.Filter(func(v int) bool { return v%2 == 0 })
+	expect(t, even, 2, 4)
+	expect(t, l, 1, 2, 3, 4)
+
+	doubled := Map(l, func(v int) int { return 2 * v })
+	expect(t, doubled, 2, 4, 6, 8)
+
+	strs := Map(l, func(v int) string { return string(rune('a' + v)) })
+
+	if strs.Length() != 4 || strs.First().Head != "b" || strs.Last().Head != "e" {
+		t.Error("Map to another type produced the wrong list")
+	}
+
+	expect(t, Map(New[int](), func(v int) int { return v }))
+}
@@ -0,0 +1,236 @@
+// Singly linked list shared by the assignment modules.
+
+package llist
+
+import "iter"
+
+// Value-based operations that LinkedList and ulist.UnrolledList share, for
+// code that doesn't hold on to items and can use either list.
+type List[T comparable] interface {
+	Length() int
+	// Inserts value at the front of the list
+	Add(value T)
+	Contains(value T) bool
+	// Removes the first occurrence of value
+	Remove(value T)
+	// Iterates over the values from first to last
+	All() iter.Seq[T]
+}
+
+type LinkedListItem[T comparable] struct {
+	Head T
+	next *LinkedListItem[T]
+}
+
+type LinkedList[T comparable] struct {
+	first  *LinkedListItem[T]
+	last   *LinkedListItem[T]
+	length int
+}
+
+func New[T comparable]() *LinkedList[T] {
+	return &LinkedList[T]{}
+}
+
+func (l *LinkedListItem[T]) Next() *LinkedListItem[T] {
+	return l.next
+}
+
+// Links next after the item. The list's cached tail and length aren't
+// updated, so call SetFirst(First()) on the list once done relinking.
+func (l *LinkedListItem[T]) SetNext(next *LinkedListItem[T]) {
+	l.next = next
+}
+
+func (l *LinkedList[T]) First() *LinkedListItem[T] {
+	return l.first
+}
+
+// Makes the chain starting at first the list's items and recounts the tail
+// and length, which takes O(n).
+func (l *LinkedList[T]) SetFirst(first *LinkedListItem[T]) {
+	l.first = first
+	l.last = nil
+	l.length = 0
+
+	for item := first; item != nil; item = item.next {
+		l.last = item
+		l.length++
+	}
+}
+
+func (l *LinkedList[T]) Last() *LinkedListItem[T] {
+	return l.last
+}
+
+func (l *LinkedList[T]) Add(value T) {
+	l.Insert(&LinkedListItem[T]{Head: value})
+}
+
+func (l *LinkedList[T]) Append(value T) *LinkedListItem[T] {
+	item := &LinkedListItem[T]{Head: value}
+
+	if l.last == nil {
+		l.first = item
+	} else {
+		l.last.next = item
+	}
+
+	l.last = item
+	l.length++
+
+	return item
+}
+
+func (l *LinkedList[T]) Length() int {
+	return l.length
+}
+
+func (l *LinkedList[T]) Find(value T) *LinkedListItem[T] {
+	for item := l.first; item != nil; item = item.next {
+		if item.Head == value {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func (l *LinkedList[T]) Contains(value T) bool {
+	return l.Find(value) != nil
+}
+
+// Inserts value after prev, or at the front if prev is nil, in O(1).
+func (l *LinkedList[T]) InsertAfter(prev *LinkedListItem[T], value T) *LinkedListItem[T] {
+	if prev == nil {
+		item := &LinkedListItem[T]{Head: value}
+		l.Insert(item)
+		return item
+	}
+
+	item := &LinkedListItem[T]{Head: value, next: prev.next}
+	prev.next = item
+
+	if l.last == prev {
+		l.last = item
+	}
+
+	l.length++
+
+	return item
+}
+
+// Unlinks and returns the item following prev, or the first item if prev is
+// nil, in O(1). Returns nil if there is no such item.
+func (l *LinkedList[T]) UnlinkAfter(prev *LinkedListItem[T]) *LinkedListItem[T] {
+	var item *LinkedListItem[T]
+
+	if prev == nil {
+		item = l.first
+	} else {
+		item = prev.next
+	}
+
+	if item == nil {
+		return nil
+	}
+
+	if prev == nil {
+		l.first = item.next
+	} else {
+		prev.next = item.next
+	}
+
+	if l.last == item {
+		l.last = prev
+	}
+
+	item.next = nil
+	l.length--
+
+	return item
+}
+
+func (l *LinkedList[T]) Remove(value T) {
+	var prev *LinkedListItem[T]
+
+	for item := l.first; item != nil; item = item.next {
+		if item.Head == value {
+			l.UnlinkAfter(prev)
+			return
+		}
+
+		prev = item
+	}
+}
+
+func (l *LinkedList[T]) Unlink(item *LinkedListItem[T]) {
+	var prev *LinkedListItem[T]
+
+	for cur := l.first; cur != nil; cur = cur.next {
+		if cur == item {
+			l.UnlinkAfter(prev)
+			return
+		}
+
+		prev = cur
+	}
+}
+
+// Links an unlinked item at the front of the list.
+func (l *LinkedList[T]) Insert(item *LinkedListItem[T]) {
+	item.next = l.first
+	l.first = item
+
+	if l.last == nil {
+		l.last = item
+	}
+
+	l.length++
+}
+
+// Iterates over the items from first to last. The current item may be
+// unlinked during iteration.
+func (l *LinkedList[T]) Items() iter.Seq[*LinkedListItem[T]] {
+	return func(yield func(*LinkedListItem[T]) bool) {
+		for item := l.first; item != nil; {
+			next := item.next
+
+			if !yield(item) {
+				return
+			}
+
+			item = next
+		}
+	}
+}
+
+// Iterates over the values from first to last.
+func (l *LinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := l.first; item != nil; item = item.next {
+			if !yield(item.Head) {
+				return
+			}
+		}
+	}
+}
+
+// Sends the items from first to last on a channel, which is closed after the
+// last item.
+//
+// Deprecated: use Items, which doesn't leave a goroutine blocked when the
+// loop stops early.
+func (l *LinkedList[T]) Iter() <-chan *LinkedListItem[T] {
+	ch := make(chan *LinkedListItem[T])
+
+	go func() {
+		for item := range l.Items() {
+			ch <- item
+		}
+
+		close(ch)
+	}()
+
+	return ch
+}
@@ -0,0 +1,29 @@
+package llist
+
+import "testing"
+
+func TestSetFirstSetNext(t *testing.T) {
+	l := fromSlice(1, 2, 3)
+
+	// Move the last item to the front by hand, as the 9-heap queues used to
+	first := l.First()
+	last := l.Last()
+	first.Next().SetNext(nil)
+	last.SetNext(first)
+	l.SetFirst(last)
+	expect(t, l, 3, 1, 2)
+
+	l.SetFirst(nil)
+	expect(t, l)
+}
+
+func TestIter(t *testing.T) {
+	l := fromSlice(1, 2, 3)
+	got := []int{}
+
+	for item := range l.Iter() {
+		got = append(got, item.Head)
+	}
+
+	expect(t, fromSlice(got...), 1, 2, 3)
+}
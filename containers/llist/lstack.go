@@ -22,7 +22,7 @@ func (s *LinkedListStack[T]) Pop() (T, error) {
 		return result, errors.New("stack is empty")
 	}
 
-	s.list.first = item.next
+	s.list.UnlinkAfter(nil)
 
 	return item.Head, nil
 }
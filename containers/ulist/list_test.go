@@ -0,0 +1,46 @@
+package ulist
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/phanty133/id1021/containers/llist"
+)
+
+var (
+	_ llist.List[int] = (*llist.LinkedList[int])(nil)
+	_ llist.List[int] = (*UnrolledList[int])(nil)
+)
+
+// Runs the same operations on both lists through llist.List and checks that
+// they end up with the same values.
+func TestSwappableWithLinkedList(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	lists := []llist.List[int]{llist.New[int](), NewWithNodeSize[int](4)}
+
+	for i := 0; i < 2000; i++ {
+		v := rng.Intn(50)
+		remove := rng.Intn(3) == 0
+
+		for _, l := range lists {
+			if remove {
+				l.Remove(v)
+			} else {
+				l.Add(v)
+			}
+		}
+
+		if lists[0].Contains(v) != lists[1].Contains(v) {
+			t.Fatalf("Contains(%d) differs", v)
+		}
+
+		if lists[0].Length() != lists[1].Length() {
+			t.Fatalf("Length: got %d and %d", lists[0].Length(), lists[1].Length())
+		}
+	}
+
+	if !slices.Equal(slices.Collect(lists[0].All()), slices.Collect(lists[1].All())) {
+		t.Fatal("lists hold different values")
+	}
+}
@@ -0,0 +1,257 @@
+// Unrolled linked list: each node holds up to a fixed number of values in an
+// array, so walking the list touches far fewer nodes and most of the work is
+// on contiguous memory.
+
+package ulist
+
+import (
+	"errors"
+	"iter"
+)
+
+const DefaultNodeSize = 64
+
+var ErrIndex = errors.New("index out of range")
+
+type node[T comparable] struct {
+	vals []T
+	next *node[T]
+}
+
+type UnrolledList[T comparable] struct {
+	first    *node[T]
+	last     *node[T]
+	length   int
+	nodeSize int
+}
+
+func New[T comparable]() *UnrolledList[T] {
+	return NewWithNodeSize[T](DefaultNodeSize)
+}
+
+// Creates a list holding up to nodeSize values per node. Nodes are split when
+// they overflow and merged with their successor when they drop below half.
+func NewWithNodeSize[T comparable](nodeSize int) *UnrolledList[T] {
+	return &UnrolledList[T]{nodeSize: max(nodeSize, 2)}
+}
+
+func (l *UnrolledList[T]) newNode() *node[T] {
+	return &node[T]{vals: make([]T, 0, l.nodeSize)}
+}
+
+func (l *UnrolledList[T]) Length() int {
+	return l.length
+}
+
+// Returns the node holding index i, its predecessor and the offset of i in it.
+// i may equal the length, in which case the last node is returned with an
+// offset past its end.
+func (l *UnrolledList[T]) locate(i int) (*node[T], *node[T], int) {
+	var prev *node[T]
+	n := l.first
+
+	for n != l.last && i >= len(n.vals) {
+		i -= len(n.vals)
+		prev = n
+		n = n.next
+	}
+
+	return prev, n, i
+}
+
+func (l *UnrolledList[T]) At(i int) (T, error) {
+	if i < 0 || i >= l.length {
+		var zero T
+		return zero, ErrIndex
+	}
+
+	_, n, off := l.locate(i)
+	return n.vals[off], nil
+}
+
+func (l *UnrolledList[T]) Set(i int, value T) error {
+	if i < 0 || i >= l.length {
+		return ErrIndex
+	}
+
+	_, n, off := l.locate(i)
+	n.vals[off] = value
+
+	return nil
+}
+
+// Inserts value at the front of the list.
+func (l *UnrolledList[T]) Add(value T) {
+	l.InsertAt(0, value)
+}
+
+func (l *UnrolledList[T]) Append(value T) {
+	if l.last == nil || len(l.last.vals) == l.nodeSize {
+		n := l.newNode()
+
+		if l.last == nil {
+			l.first = n
+		} else {
+			l.last.next = n
+		}
+
+		l.last = n
+	}
+
+	l.last.vals = append(l.last.vals, value)
+	l.length++
+}
+
+// Inserts value so that it ends up at index i, shifting the values after it.
+func (l *UnrolledList[T]) InsertAt(i int, value T) error {
+	if i < 0 || i > l.length {
+		return ErrIndex
+	}
+
+	if l.first == nil {
+		l.Append(value)
+		return nil
+	}
+
+	_, n, off := l.locate(i)
+
+	if len(n.vals) == l.nodeSize {
+		// Move the upper half into a new node and insert into whichever
+		// half the index falls in
+		half := l.nodeSize / 2
+		split := l.newNode()
+		split.vals = append(split.vals, n.vals[half:]...)
+		split.next = n.next
+
+		clear(n.vals[half:])
+		n.vals = n.vals[:half]
+		n.next = split
+
+		if l.last == n {
+			l.last = split
+		}
+
+		if off > half {
+			n = split
+			off -= half
+		}
+	}
+
+	n.vals = append(n.vals, value)
+	copy(n.vals[off+1:], n.vals[off:])
+	n.vals[off] = value
+	l.length++
+
+	return nil
+}
+
+func (l *UnrolledList[T]) RemoveAt(i int) (T, error) {
+	if i < 0 || i >= l.length {
+		var zero T
+		return zero, ErrIndex
+	}
+
+	prev, n, off := l.locate(i)
+	value := n.vals[off]
+	l.removeFrom(prev, n, off)
+
+	return value, nil
+}
+
+// Removes the value at off from node n, whose predecessor is prev.
+func (l *UnrolledList[T]) removeFrom(prev, n *node[T], off int) {
+	var zero T
+
+	copy(n.vals[off:], n.vals[off+1:])
+	n.vals[len(n.vals)-1] = zero
+	n.vals = n.vals[:len(n.vals)-1]
+	l.length--
+
+	if len(n.vals) == 0 {
+		l.unlinkNode(prev, n)
+		return
+	}
+
+	// Keep nodes at least half full by merging with or borrowing from the
+	// next node
+	half := l.nodeSize / 2
+	next := n.next
+
+	if len(n.vals) >= half || next == nil {
+		return
+	}
+
+	if len(n.vals)+len(next.vals) <= l.nodeSize {
+		n.vals = append(n.vals, next.vals...)
+		l.unlinkNode(n, next)
+		return
+	}
+
+	moved := half - len(n.vals)
+	n.vals = append(n.vals, next.vals[:moved]...)
+	copy(next.vals, next.vals[moved:])
+	clear(next.vals[len(next.vals)-moved:])
+	next.vals = next.vals[:len(next.vals)-moved]
+}
+
+func (l *UnrolledList[T]) unlinkNode(prev, n *node[T]) {
+	if prev == nil {
+		l.first = n.next
+	} else {
+		prev.next = n.next
+	}
+
+	if l.last == n {
+		l.last = prev
+	}
+}
+
+// Returns the index of the first occurrence of value, or -1.
+func (l *UnrolledList[T]) Find(value T) int {
+	i := 0
+
+	for n := l.first; n != nil; n = n.next {
+		for _, v := range n.vals {
+			if v == value {
+				return i
+			}
+
+			i++
+		}
+	}
+
+	return -1
+}
+
+func (l *UnrolledList[T]) Contains(value T) bool {
+	return l.Find(value) != -1
+}
+
+// Removes the first occurrence of value.
+func (l *UnrolledList[T]) Remove(value T) {
+	var prev *node[T]
+
+	for n := l.first; n != nil; n = n.next {
+		for off, v := range n.vals {
+			if v == value {
+				l.removeFrom(prev, n, off)
+				return
+			}
+		}
+
+		prev = n
+	}
+}
+
+// Iterates over the values from first to last.
+func (l *UnrolledList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.first; n != nil; n = n.next {
+			for _, v := range n.vals {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
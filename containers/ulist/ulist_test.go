@@ -0,0 +1,93 @@
+package ulist
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Applies random operations to the list and to a slice and compares them.
+func TestAgainstSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, nodeSize := range []int{2, 3, 4, 16} {
+		l := NewWithNodeSize[int](nodeSize)
+		want := []int{}
+
+		for step := 0; step < 5000; step++ {
+			v := rng.Intn(50)
+
+			switch op := rng.Intn(7); {
+			case op == 0:
+				l.Append(v)
+				want = append(want, v)
+			case op == 1:
+				l.Add(v)
+				want = slices.Insert(want, 0, v)
+			case op == 2:
+				i := rng.Intn(len(want) + 1)
+
+				if err := l.InsertAt(i, v); err != nil {
+					t.Fatal(err)
+				}
+
+				want = slices.Insert(want, i, v)
+			case op == 3 && len(want) > 0:
+				i := rng.Intn(len(want))
+				got, err := l.RemoveAt(i)
+
+				if err != nil || got != want[i] {
+					t.Fatalf("RemoveAt(%d): got %d, %v, want %d", i, got, err, want[i])
+				}
+
+				want = slices.Delete(want, i, i+1)
+			case op == 4:
+				l.Remove(v)
+
+				if i := slices.Index(want, v); i >= 0 {
+					want = slices.Delete(want, i, i+1)
+				}
+			case op == 5:
+				if got := l.Find(v); got != slices.Index(want, v) {
+					t.Fatalf("Find(%d): got %d, want %d", v, got, slices.Index(want, v))
+				}
+			case op == 6 && len(want) > 0:
+				i := rng.Intn(len(want))
+				l.Set(i, v)
+				want[i] = v
+
+				if got, _ := l.At(i); got != v {
+					t.Fatalf("At(%d) after Set: got %d, want %d", i, got, v)
+				}
+			}
+
+			if got := slices.Collect(l.All()); !slices.Equal(got, want) || l.Length() != len(want) {
+				t.Fatalf("node size %d, step %d: got %v (length %d), want %v", nodeSize, step, got, l.Length(), want)
+			}
+		}
+	}
+}
+
+func TestIndexErrors(t *testing.T) {
+	l := New[int]()
+
+	if _, err := l.At(0); err != ErrIndex {
+		t.Error("At on empty list didn't fail")
+	}
+
+	if _, err := l.RemoveAt(0); err != ErrIndex {
+		t.Error("RemoveAt on empty list didn't fail")
+	}
+
+	if err := l.InsertAt(1, 0); err != ErrIndex {
+		t.Error("InsertAt past the end didn't fail")
+	}
+
+	if err := l.InsertAt(0, 1); err != nil {
+		t.Error(err)
+	}
+
+	if err := l.Set(-1, 0); err != ErrIndex {
+		t.Error("Set at negative index didn't fail")
+	}
+}